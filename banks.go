@@ -0,0 +1,285 @@
+package safe
+
+import (
+	"regexp"
+	"strings"
+)
+
+// A brazilian bank, or payment institution, as registered by BACEN.
+type Bank struct {
+	// The COMPE code, like "001"
+	Code string
+	// The ISPB code, like "00000000"
+	ISPB string
+	Name string
+}
+
+// The banks known by safe.BankByCode and safe.BankCode, by their COMPE and ISPB codes.
+var banks = []Bank{
+	{"001", "00000000", "Banco do Brasil"},
+	{"003", "04902979", "Banco da Amazônia"},
+	{"004", "07237373", "Banco do Nordeste"},
+	{"021", "28127603", "Banestes"},
+	{"033", "90400888", "Santander"},
+	{"037", "04913711", "Banpará"},
+	{"041", "92702067", "Banrisul"},
+	{"047", "13009717", "Banese"},
+	{"070", "00000208", "BRB"},
+	{"077", "00416968", "Banco Inter"},
+	{"102", "02332886", "XP Investimentos"},
+	{"104", "00360305", "Caixa Econômica Federal"},
+	{"136", "00315557", "Unicred"},
+	{"197", "16501555", "Stone"},
+	{"208", "30306294", "BTG Pactual"},
+	{"212", "92894922", "Banco Original"},
+	{"237", "60746948", "Bradesco"},
+	{"260", "18236120", "Nu Pagamentos (Nubank)"},
+	{"290", "08561701", "PagSeguro"},
+	{"318", "61186680", "Banco BMG"},
+	{"323", "10573521", "Mercado Pago"},
+	{"336", "31872495", "C6 Bank"},
+	{"341", "60701190", "Itaú Unibanco"},
+	{"364", "09089356", "Efí (Gerencianet)"},
+	{"380", "22896431", "PicPay"},
+	{"389", "17184037", "Mercantil do Brasil"},
+	{"403", "37880206", "Cora"},
+	{"422", "58160789", "Safra"},
+	{"623", "59285411", "Banco Pan"},
+	{"633", "68900810", "Banco Rendimento"},
+	{"637", "60889128", "Sofisa"},
+	{"655", "59588111", "Banco Votorantim (BV)"},
+	{"707", "62232889", "Daycoval"},
+	{"739", "00558456", "Cetelem"},
+	{"741", "00517645", "Banco Ribeirão Preto"},
+	{"745", "33479023", "Citibank"},
+	{"746", "30723886", "Banco Modal"},
+	{"748", "01181521", "Sicredi"},
+	{"756", "02038232", "Sicoob"},
+}
+
+// A helper function to find a bank by its COMPE code, like "001", or its ISPB code, like "00000000".
+//
+// The second result is false when the bank is unknown.
+func BankByCode(code string) (Bank, bool) {
+	code = strings.TrimSpace(code)
+	for _, bank := range banks {
+		if bank.Code == code || bank.ISPB == code {
+			return bank, true
+		}
+	}
+	return Bank{}, false
+}
+
+// The format and check digit algorithms of agencies and accounts of a bank.
+type bankAccountFormat struct {
+	agencyLength int
+	// nil when agencies have no check digit
+	agencyCheckDigit func(agency []int) byte
+	// the only agency of some digital banks, like "0001"
+	fixedAgency string
+	// the maximum length of accounts, without the check digit. Shorter ones are padded with zeros on the left.
+	accountLength int
+	// nil when the algorithm is not public, in which case any check digit is accepted
+	accountCheckDigit func(agency, account []int) byte
+}
+
+// The banks whose agencies and accounts are fully verified. Other banks get the generic format only.
+var bankAccountFormats = map[string]*bankAccountFormat{
+	"001": {
+		agencyLength:      4,
+		agencyCheckDigit:  func(agency []int) byte { return bbCheckDigit(agency, []int{5, 4, 3, 2}) },
+		accountLength:     8,
+		accountCheckDigit: func(_, account []int) byte { return bbCheckDigit(account, descendingWeights(9, 8)) },
+	},
+	"237": {
+		agencyLength:      4,
+		agencyCheckDigit:  func(agency []int) byte { return bradescoCheckDigit(agency, []int{5, 4, 3, 2}) },
+		accountLength:     7,
+		accountCheckDigit: func(_, account []int) byte { return bradescoCheckDigit(account, []int{2, 7, 6, 5, 4, 3, 2}) },
+	},
+	"341": {
+		agencyLength:  4,
+		accountLength: 5,
+		accountCheckDigit: func(agency, account []int) byte {
+			digits := append(append([]int{}, agency...), account...)
+			return byte('0' + mod10CheckDigit(digits))
+		},
+	},
+	"033": {
+		agencyLength:  4,
+		accountLength: 8,
+		accountCheckDigit: func(agency, account []int) byte {
+			digits := append(append(append([]int{}, agency...), 0, 0), account...)
+			sum := 0
+			for i, w := range []int{9, 7, 3, 1, 0, 0, 9, 7, 1, 3, 1, 9, 7, 3} {
+				sum += digits[i] * w % 10
+			}
+			return byte('0' + (10-sum%10)%10)
+		},
+	},
+	"104": {
+		agencyLength: 4,
+		// the operation (3 digits) followed by the account number (8 digits)
+		accountLength: 11,
+		accountCheckDigit: func(agency, account []int) byte {
+			digits := append(append([]int{}, agency...), account...)
+			checkDigit := weightedSum(digits, []int{8, 7, 6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) * 10 % 11
+			if checkDigit == 10 {
+				checkDigit = 0
+			}
+			return byte('0' + checkDigit)
+		},
+	},
+	"041": {
+		agencyLength:  4,
+		accountLength: 9,
+		accountCheckDigit: func(_, account []int) byte {
+			switch rest := weightedSum(account, []int{3, 2, 4, 7, 6, 5, 4, 3, 2}) % 11; rest {
+			case 0:
+				return '0'
+			case 1:
+				return '6'
+			default:
+				return byte('0' + 11 - rest)
+			}
+		},
+	},
+	"260": {agencyLength: 4, fixedAgency: "0001", accountLength: 10},
+	"077": {agencyLength: 4, fixedAgency: "0001", accountLength: 10},
+	"336": {agencyLength: 4, fixedAgency: "0001", accountLength: 10},
+}
+
+// Agencies of up to 5 digits, with an optional check digit, and accounts of up to 13 digits and a check digit.
+var (
+	genericAgencyRegex  = regexp.MustCompile(`^\d{1,5}(?:-?[0-9X])?$`)
+	genericAccountRegex = regexp.MustCompile(`^\d{1,13}-?[0-9X]$`)
+)
+
+// A COMPE code (3 digits) or an ISPB code (8 digits).
+var bankCodeRegex = regexp.MustCompile(`^(?:\d{3}|\d{8})$`)
+
+// Returns the format of agencies and accounts of a bank, by its COMPE or ISPB code, or nil when it has the generic one.
+//
+// The second result is false when the code is not well formed.
+func bankAccountFormatOf(bankCode string) (*bankAccountFormat, bool) {
+	if bank, ok := BankByCode(bankCode); ok {
+		return bankAccountFormats[bank.Code], true
+	}
+	return nil, bankCodeRegex.MatchString(strings.TrimSpace(bankCode))
+}
+
+// The check digits of Banco do Brasil: 10 becomes X and 11 becomes 0.
+func bbCheckDigit(digits, weights []int) byte {
+	switch checkDigit := 11 - weightedSum(digits, weights)%11; checkDigit {
+	case 10:
+		return 'X'
+	case 11:
+		return '0'
+	default:
+		return byte('0' + checkDigit)
+	}
+}
+
+// The check digits of Bradesco: 10 becomes P and 11 becomes 0.
+func bradescoCheckDigit(digits, weights []int) byte {
+	switch checkDigit := 11 - weightedSum(digits, weights)%11; checkDigit {
+	case 10:
+		return 'P'
+	case 11:
+		return '0'
+	default:
+		return byte('0' + checkDigit)
+	}
+}
+
+// Splits a number like "1584-9" into its digits, padded with zeros on the left up to length, and its check digit.
+func splitCheckDigit(number string, length int) ([]int, byte, bool) {
+	number = strings.ToUpper(stripSymbols(strings.TrimSpace(number), " .-"))
+	if len(number) < 2 || len(number)-1 > length {
+		return nil, 0, false
+	}
+
+	digits, ok := parseDigits(number[:len(number)-1])
+	if !ok {
+		return nil, 0, false
+	}
+
+	return append(make([]int, length-len(digits)), digits...), number[len(number)-1], true
+}
+
+// Returns the digits of an agency, without its check digit, padded with zeros on the left.
+func agencyDigits(format *bankAccountFormat, agency string) ([]int, bool) {
+	agency = stripSymbols(strings.TrimSpace(agency), " .-")
+	if format.agencyCheckDigit != nil {
+		digits, _, ok := splitCheckDigit(agency, format.agencyLength)
+		return digits, ok
+	}
+
+	digits, ok := parseDigits(agency)
+	if !ok || len(digits) == 0 || len(digits) > format.agencyLength {
+		return nil, false
+	}
+	return append(make([]int, format.agencyLength-len(digits)), digits...), true
+}
+
+// A helper function to determine if an agency of the given bank (COMPE or ISPB code) is valid,
+// with or without dash (-), like "1584-9".
+//
+// The check digits of Banco do Brasil and Bradesco are verified, and Itaú, Santander, Caixa and Banrisul agencies
+// must have up to 4 digits, with no check digit. Nubank, Inter and C6 only have the agency "0001".
+// Agencies of other banks, including the ones unknown by safe.BankByCode, must have up to 5 digits,
+// and an optional check digit.
+//
+// The bank code must be well formed, with 3 (COMPE) or 8 (ISPB) digits.
+func IsValidBankAgency(bankCode, agency string) bool {
+	format, ok := bankAccountFormatOf(bankCode)
+	if !ok {
+		return false
+	}
+
+	if format == nil {
+		return genericAgencyRegex.MatchString(strings.ToUpper(strings.TrimSpace(agency)))
+	}
+
+	if format.fixedAgency != "" {
+		return stripSymbols(strings.TrimSpace(agency), " .-") == format.fixedAgency
+	}
+
+	if format.agencyCheckDigit == nil {
+		_, ok := agencyDigits(format, agency)
+		return ok
+	}
+
+	digits, checkDigit, ok := splitCheckDigit(agency, format.agencyLength)
+	return ok && checkDigit == format.agencyCheckDigit(digits)
+}
+
+// A helper function to determine if an account of the given bank (COMPE or ISPB code) and agency is valid,
+// with or without dash (-), like "00210169-6".
+//
+// Banco do Brasil, Bradesco, Itaú, Santander, Caixa (operation and number, like "001-00000448-6")
+// and Banrisul accounts have their check digits verified. Accounts of other banks, including the ones unknown
+// by safe.BankByCode, must have up to 13 digits and a check digit. The agency must be valid as well,
+// as in safe.IsValidBankAgency.
+func IsValidBankAccount(bankCode, agency, account string) bool {
+	if !IsValidBankAgency(bankCode, agency) {
+		return false
+	}
+
+	format, _ := bankAccountFormatOf(bankCode)
+	if format == nil {
+		return genericAccountRegex.MatchString(strings.ToUpper(strings.TrimSpace(account)))
+	}
+
+	digits, checkDigit, ok := splitCheckDigit(account, format.accountLength)
+	if !ok {
+		return false
+	}
+
+	if format.accountCheckDigit == nil {
+		return checkDigit >= '0' && checkDigit <= '9'
+	}
+
+	agencyDigits, _ := agencyDigits(format, agency)
+	return checkDigit == format.accountCheckDigit(agencyDigits, digits)
+}
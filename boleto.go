@@ -0,0 +1,182 @@
+package safe
+
+import "time"
+
+// The kind of a boleto.
+type BoletoKind string
+
+const (
+	// Issued by banks, with a 44 digit barcode and a 47 digit linha digitável
+	BoletoBancario BoletoKind = "bancario"
+	// Issued by utilities and government agencies (convênio), with a 44 digit barcode and a 48 digit linha digitável
+	BoletoArrecadacao BoletoKind = "arrecadacao"
+)
+
+// The date of the due date factor 1000. Factors go up to 9999 and then start over at 1000.
+var boletoFactorBase = time.Date(2000, time.July, 3, 0, 0, 0, 0, time.UTC)
+
+// A boleto, as parsed by safe.ParseBoleto.
+type Boleto struct {
+	Kind BoletoKind
+	// The 44 digits of the barcode
+	Barcode string
+	// The code of the issuing bank, like "001". Only for bank boletos.
+	BankCode string
+	// The amount in cents. Zero when the boleto doesn't have one.
+	Amount int64
+	// The due date. Only for bank boletos, and zero when the boleto doesn't have one.
+	DueDate time.Time
+}
+
+// A helper function to parse a boleto out of its barcode or its linha digitável.
+//
+// It accepts the 44 digit barcode and the 47 digit linha digitável of bank boletos, as well as the 44 digit barcode
+// and the 48 digit linha digitável of convênio (arrecadação) slips. Whitespaces, dots and dashes are ignored.
+// The check digit of each field, and the general check digit, must be valid.
+//
+// Due date factors start over every 9000 days, so the due date is the one closest to the current date.
+// Please refer to safe.ParseBoletoAt to provide another reference date.
+//
+// The second result is false when the boleto is not valid.
+//
+// Example usage:
+//
+//	boleto, ok := safe.ParseBoleto("00190.50095 40144.816069 06809.350314 3 37370000000100")
+//	boleto.BankCode // "001"
+//	boleto.Amount   // 100
+func ParseBoleto(code string) (Boleto, bool) {
+	return ParseBoletoAt(code, time.Now())
+}
+
+// Just like safe.ParseBoleto, but the due date is the one closest to the given reference date, instead of
+// the current date. This is useful to parse old boletos, or to make tests deterministic.
+func ParseBoletoAt(code string, now time.Time) (Boleto, bool) {
+	code = stripSymbols(code, " .-")
+	if _, ok := parseDigits(code); !ok {
+		return Boleto{}, false
+	}
+
+	switch len(code) {
+	case 44:
+		return parseBoletoBarcode(code, now)
+	case 47:
+		if !boletoLinhaDigitavelFieldsAreValid(code) {
+			return Boleto{}, false
+		}
+		barcode := code[0:4] + code[32:47] + code[4:9] + code[10:20] + code[21:31]
+		return parseBoletoBarcode(barcode, now)
+	case 48:
+		if code[0] != '8' {
+			return Boleto{}, false
+		}
+		barcode := ""
+		for i := 0; i < 4; i++ {
+			block := code[i*12 : i*12+11]
+			checkDigit, ok := arrecadacaoCheckDigit(code[2], block)
+			if !ok || int(code[i*12+11]-'0') != checkDigit {
+				return Boleto{}, false
+			}
+			barcode += block
+		}
+		return parseBoletoBarcode(barcode, now)
+	}
+
+	return Boleto{}, false
+}
+
+// Checks the mod 10 check digits of the first three fields of a bank linha digitável.
+func boletoLinhaDigitavelFieldsAreValid(linha string) bool {
+	fields := [][2]int{{0, 9}, {10, 20}, {21, 31}}
+	for _, field := range fields {
+		digits, _ := parseDigits(linha[field[0]:field[1]])
+		if int(linha[field[1]]-'0') != mod10CheckDigit(digits) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseBoletoBarcode(barcode string, now time.Time) (Boleto, bool) {
+	if barcode[0] == '8' {
+		return parseArrecadacaoBarcode(barcode)
+	}
+
+	digits, _ := parseDigits(barcode[:4] + barcode[5:])
+	checkDigit := mod11CycleCheckDigit(digits)
+	if checkDigit == 0 {
+		checkDigit = 1
+	}
+	if int(barcode[4]-'0') != checkDigit {
+		return Boleto{}, false
+	}
+
+	boleto := Boleto{
+		Kind:     BoletoBancario,
+		Barcode:  barcode,
+		BankCode: barcode[:3],
+		Amount:   int64(digitsToInt(digits[8:18])),
+	}
+
+	if factor := digitsToInt(digits[4:8]); factor >= 1000 {
+		boleto.DueDate = boletoDueDate(factor, now)
+	}
+
+	return boleto, true
+}
+
+func parseArrecadacaoBarcode(barcode string) (Boleto, bool) {
+	checkDigit, ok := arrecadacaoCheckDigit(barcode[2], barcode[:3]+barcode[4:])
+	if !ok || int(barcode[3]-'0') != checkDigit {
+		return Boleto{}, false
+	}
+
+	boleto := Boleto{Kind: BoletoArrecadacao, Barcode: barcode}
+
+	// 6 and 8 mean the amount is in reais; 7 and 9 mean it is a reference value
+	if barcode[2] == '6' || barcode[2] == '8' {
+		digits, _ := parseDigits(barcode[4:15])
+		boleto.Amount = int64(digitsToInt(digits))
+	}
+
+	return boleto, true
+}
+
+// The check digit of a convênio (arrecadação) slip, which is either mod 10 or mod 11, according to its value identifier.
+func arrecadacaoCheckDigit(valueIdentifier byte, block string) (int, bool) {
+	digits, _ := parseDigits(block)
+	switch valueIdentifier {
+	case '6', '7':
+		return mod10CheckDigit(digits), true
+	case '8', '9':
+		return mod11CycleCheckDigit(digits), true
+	}
+	return 0, false
+}
+
+// The mod 10 check digit, weighting digits from right to left with 2 and 1, alternately,
+// and summing the digits of each product.
+func mod10CheckDigit(digits []int) int {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		product := digits[i] * weight
+		sum += product/10 + product%10
+		weight = 3 - weight
+	}
+	return (10 - sum%10) % 10
+}
+
+// Returns the date of a due date factor that is the closest to now.
+func boletoDueDate(factor int, now time.Time) time.Time {
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	dueDate := boletoFactorBase.AddDate(0, 0, factor-1000)
+	for {
+		next := dueDate.AddDate(0, 0, 9000)
+		if next.Sub(today) >= today.Sub(dueDate) {
+			return dueDate
+		}
+		dueDate = next
+	}
+}
@@ -0,0 +1,140 @@
+package safe
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The brand of a payment card.
+type CardBrand string
+
+const (
+	CardVisa       CardBrand = "visa"
+	CardMastercard CardBrand = "mastercard"
+	CardAmex       CardBrand = "amex"
+	CardElo        CardBrand = "elo"
+	CardHipercard  CardBrand = "hipercard"
+	CardDiners     CardBrand = "diners"
+	CardDiscover   CardBrand = "discover"
+	CardJCB        CardBrand = "jcb"
+)
+
+// The ranges of prefixes (IIN) and the lengths of the numbers of a card brand.
+type cardBrandRange struct {
+	brand CardBrand
+	// inclusive ranges of prefixes with the same length, like {"2221", "2720"}
+	prefixes [][2]string
+	lengths  []int
+}
+
+// The known card brands. Elo and Hipercard come first, since their ranges overlap the ones of other brands.
+var cardBrandRanges = []cardBrandRange{
+	{CardElo, [][2]string{
+		{"401178", "401179"}, {"431274", "431274"}, {"438935", "438935"}, {"451416", "451416"},
+		{"457393", "457393"}, {"457631", "457632"}, {"504175", "504175"}, {"506699", "506778"},
+		{"509000", "509999"}, {"627780", "627780"}, {"636297", "636297"}, {"636368", "636368"},
+		{"650031", "650033"}, {"650035", "650051"}, {"650405", "650439"}, {"650485", "650538"},
+		{"650541", "650598"}, {"650700", "650718"}, {"650720", "650727"}, {"650901", "650978"},
+		{"651652", "651679"}, {"655000", "655019"}, {"655021", "655058"},
+	}, []int{16}},
+	{CardHipercard, [][2]string{{"606282", "606282"}, {"384100", "384100"}, {"384140", "384140"}, {"384160", "384160"}}, []int{13, 16, 19}},
+	{CardAmex, [][2]string{{"34", "34"}, {"37", "37"}}, []int{15}},
+	{CardDiners, [][2]string{{"300", "305"}, {"36", "36"}, {"38", "39"}}, []int{14, 16}},
+	{CardJCB, [][2]string{{"3528", "3589"}}, []int{16, 17, 18, 19}},
+	{CardDiscover, [][2]string{{"6011", "6011"}, {"644", "649"}, {"65", "65"}}, []int{16, 17, 18, 19}},
+	{CardMastercard, [][2]string{{"51", "55"}, {"2221", "2720"}}, []int{16}},
+	{CardVisa, [][2]string{{"4", "4"}}, []int{13, 16, 19}},
+}
+
+// Expiry dates like "12/29" or "12/2029".
+var cardExpiryRegex = regexp.MustCompile(`^(0[1-9]|1[0-2])\s*/\s*(\d{2}|\d{4})$`)
+
+// A helper function to determine if a card number is valid, with or without whitespaces and dashes (-).
+//
+// It must have from 12 to 19 digits, and a valid check digit (Luhn). The brand is not checked,
+// please refer to safe.CardBrandOf.
+func IsValidCardNumber(number string) bool {
+	number = stripSymbols(number, " -")
+	if len(number) < 12 || len(number) > 19 {
+		return false
+	}
+
+	digits, ok := parseDigits(number)
+	if !ok {
+		return false
+	}
+
+	// the Luhn check digit is the same mod 10 check digit of boletos
+	return digits[len(digits)-1] == mod10CheckDigit(digits[:len(digits)-1])
+}
+
+// A helper function to find out the brand of a card number, with or without whitespaces and dashes (-).
+//
+// Visa, Mastercard, American Express, Elo, Hipercard, Diners Club, Discover and JCB are detected
+// by the prefix and the length of the number.
+//
+// The second result is false when the number is not valid, as in safe.IsValidCardNumber, or its brand is unknown.
+func CardBrandOf(number string) (CardBrand, bool) {
+	number = stripSymbols(number, " -")
+	if !IsValidCardNumber(number) {
+		return "", false
+	}
+
+	for _, r := range cardBrandRanges {
+		if !cardPrefixMatches(number, r.prefixes) {
+			continue
+		}
+		for _, length := range r.lengths {
+			if len(number) == length {
+				return r.brand, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func cardPrefixMatches(number string, prefixes [][2]string) bool {
+	for _, p := range prefixes {
+		prefix := number[:len(p[0])]
+		if prefix >= p[0] && prefix <= p[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// A helper function to parse the expiry date of a card, like "12/29" or "12/2029".
+//
+// The second result is false when the expiry date has an invalid format. It is not compared to the current date,
+// please refer to safe.CardExpiry.
+func ParseCardExpiry(expiry string) (year int, month time.Month, ok bool) {
+	matches := cardExpiryRegex.FindStringSubmatch(strings.TrimSpace(expiry))
+	if matches == nil {
+		return 0, 0, false
+	}
+
+	m, _ := strconv.Atoi(matches[1])
+	year, _ = strconv.Atoi(matches[2])
+	if len(matches[2]) == 2 {
+		year += 2000
+	}
+
+	return year, time.Month(m), true
+}
+
+// Cards are valid up to the last day of their expiry month.
+func isCardExpired(year int, month time.Month, now time.Time) bool {
+	nowYear, nowMonth, _ := now.Date()
+	return year < nowYear || year == nowYear && month < nowMonth
+}
+
+// The length of the security code of a card brand. American Express cards have 4 digits, and the others have 3.
+func cvvLength(brand CardBrand) int {
+	if brand == CardAmex {
+		return 4
+	}
+	return 3
+}
@@ -0,0 +1,61 @@
+package safe
+
+// A range of ceps, as 8 digit numbers.
+type cepRange struct {
+	first, last int
+	uf          string
+}
+
+// The ranges of ceps of each state (uf), according to Correios.
+var cepRanges = []cepRange{
+	{1000000, 19999999, "SP"},
+	{20000000, 28999999, "RJ"},
+	{29000000, 29999999, "ES"},
+	{30000000, 39999999, "MG"},
+	{40000000, 48999999, "BA"},
+	{49000000, 49999999, "SE"},
+	{50000000, 56999999, "PE"},
+	{57000000, 57999999, "AL"},
+	{58000000, 58999999, "PB"},
+	{59000000, 59999999, "RN"},
+	{60000000, 63999999, "CE"},
+	{64000000, 64999999, "PI"},
+	{65000000, 65999999, "MA"},
+	{66000000, 68899999, "PA"},
+	{68900000, 68999999, "AP"},
+	{69000000, 69299999, "AM"},
+	{69300000, 69399999, "RR"},
+	{69400000, 69899999, "AM"},
+	{69900000, 69999999, "AC"},
+	{70000000, 72799999, "DF"},
+	{72800000, 72999999, "GO"},
+	{73000000, 73699999, "DF"},
+	{73700000, 76799999, "GO"},
+	{76800000, 76999999, "RO"},
+	{77000000, 77999999, "TO"},
+	{78000000, 78899999, "MT"},
+	{79000000, 79999999, "MS"},
+	{80000000, 87999999, "PR"},
+	{88000000, 89999999, "SC"},
+	{90000000, 99999999, "RS"},
+}
+
+// A helper function to find out the state (uf) of a cep, with or without dash (-), like "MG" for "37500-000".
+//
+// The second result is false when the cep has an invalid format, or doesn't belong to any state.
+func UFFromCEP(cep string) (string, bool) {
+	if !CepRegex.MatchString(cep) {
+		return "", false
+	}
+
+	digits, _ := parseDigits(stripSymbols(cep, "-"))
+	number := digitsToInt(digits)
+
+	for _, r := range cepRanges {
+		if number >= r.first && number <= r.last {
+			return r.uf, true
+		}
+	}
+
+	return "", false
+}
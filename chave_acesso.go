@@ -0,0 +1,116 @@
+package safe
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// The IBGE codes of each state (uf).
+var ibgeUFCodes = map[string]string{
+	"11": "RO", "12": "AC", "13": "AM", "14": "RR", "15": "PA", "16": "AP", "17": "TO",
+	"21": "MA", "22": "PI", "23": "CE", "24": "RN", "25": "PB", "26": "PE", "27": "AL", "28": "SE", "29": "BA",
+	"31": "MG", "32": "ES", "33": "RJ", "35": "SP",
+	"41": "PR", "42": "SC", "43": "RS",
+	"50": "MS", "51": "MT", "52": "GO", "53": "DF",
+}
+
+// The models of fiscal documents identified by a chave de acesso.
+var chaveAcessoModels = map[string]string{
+	"55": "NF-e",
+	"57": "CT-e",
+	"58": "MDF-e",
+	"59": "CF-e SAT",
+	"62": "NFCom",
+	"63": "BP-e",
+	"65": "NFC-e",
+	"66": "NF3e",
+	"67": "CT-e OS",
+}
+
+// The emitter may be an alphanumeric cnpj.
+var chaveAcessoRegex = regexp.MustCompile(`^\d{6}[0-9A-Z]{12}\d{26}$`)
+
+// The components of a chave de acesso, as parsed by safe.ParseChaveAcesso.
+type ChaveAcessoComponents struct {
+	// The state (uf) of the emitter, like "SP"
+	UF string
+	// The IBGE code of the state, like "35"
+	UFCode string
+	// The year and month of issue
+	Year  int
+	Month time.Month
+	// The cnpj of the emitter, with 14 characters. Individuals have their cpf padded with zeros on the left.
+	Emitter string
+	// The model of the document, like "55" for NF-e and "65" for NFC-e
+	Model        string
+	Series       string
+	Number       string
+	EmissionType string
+	// The random code chosen by the emitter
+	Code       string
+	CheckDigit string
+}
+
+// Returns the name of the model of the document, like "NF-e".
+func (ca ChaveAcessoComponents) ModelName() string {
+	return chaveAcessoModels[ca.Model]
+}
+
+// A helper function to parse the 44 characters of a chave de acesso of NF-e, NFC-e, CT-e and other fiscal documents.
+//
+// Whitespaces are ignored. The check digit (mod 11), the IBGE code of the state, the month of issue
+// and the model of the document must be valid.
+//
+// The emitter is not validated, since it may be a cnpj or a cpf. It can be cross-checked with safe.IsValidCnpj.
+//
+// The second result is false when the chave de acesso is not valid.
+//
+// Example usage:
+//
+//	chave, ok := safe.ParseChaveAcesso("3524 1045 7698 5200 0186 5500 1000 0001 2311 2345 6783")
+//	chave.UF      // "SP"
+//	chave.Emitter // "45769852000186"
+func ParseChaveAcesso(chave string) (ChaveAcessoComponents, bool) {
+	chave = stripSymbols(chave, " ")
+	if !chaveAcessoRegex.MatchString(chave) {
+		return ChaveAcessoComponents{}, false
+	}
+
+	values := make([]int, 0, 43)
+	for _, r := range chave[:43] {
+		values = append(values, int(r-'0'))
+	}
+	if int(chave[43]-'0') != mod11CycleCheckDigit(values) {
+		return ChaveAcessoComponents{}, false
+	}
+
+	uf, ok := ibgeUFCodes[chave[:2]]
+	if !ok {
+		return ChaveAcessoComponents{}, false
+	}
+
+	if _, ok := chaveAcessoModels[chave[20:22]]; !ok {
+		return ChaveAcessoComponents{}, false
+	}
+
+	year, _ := strconv.Atoi(chave[2:4])
+	month, _ := strconv.Atoi(chave[4:6])
+	if month < 1 || month > 12 {
+		return ChaveAcessoComponents{}, false
+	}
+
+	return ChaveAcessoComponents{
+		UF:           uf,
+		UFCode:       chave[:2],
+		Year:         2000 + year,
+		Month:        time.Month(month),
+		Emitter:      chave[6:20],
+		Model:        chave[20:22],
+		Series:       chave[22:25],
+		Number:       chave[25:34],
+		EmissionType: chave[34:35],
+		Code:         chave[35:43],
+		CheckDigit:   chave[43:],
+	}, true
+}
@@ -0,0 +1,563 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cayo-rodrigues/safe"
+)
+
+// Rules that take no parameter, and the name of their constructor.
+var simpleRules = map[string]string{
+	"required":       "Required",
+	"true":           "True",
+	"false":          "False",
+	"email":          "Email",
+	"phone":          "Phone",
+	"mobilephone":    "MobilePhone",
+	"landlinephone":  "LandlinePhone",
+	"cpf":            "Cpf",
+	"cnpj":           "Cnpj",
+	"cpfcnpj":        "CpfCnpj",
+	"pis":            "Pis",
+	"tituloeleitor":  "TituloEleitor",
+	"cnh":            "Cnh",
+	"cns":            "Cns",
+	"cep":            "CEP",
+	"strongpassword": "StrongPassword",
+	"uuid":           "UUIDstr",
+	"pixkey":         "PixKey",
+	"plate":          "Plate",
+	"renavam":        "Renavam",
+	"vin":            "VIN",
+	"vincheckdigit":  "VINWithCheckDigit",
+	"cardnumber":     "CardNumber",
+	"cardexpiry":     "CardExpiry",
+	"iban":           "IBAN",
+	"bic":            "BIC",
+}
+
+type generator struct {
+	fset      *token.FileSet
+	pkgName   string
+	types     map[string]*ast.TypeSpec
+	order     []string
+	generated map[string]bool
+
+	buf        bytes.Buffer
+	regexes    bytes.Buffer
+	usesRegexp bool
+}
+
+// Parses the Go files in dir and returns the formatted source of the SafeFields methods for the given types.
+//
+// The file at outputPath is skipped while parsing, since it is the one being generated.
+func generate(dir string, typeNames []string, outputPath string) ([]byte, error) {
+	g := &generator{
+		fset:      token.NewFileSet(),
+		types:     make(map[string]*ast.TypeSpec),
+		generated: make(map[string]bool),
+	}
+
+	if err := g.parseDir(dir, outputPath); err != nil {
+		return nil, err
+	}
+
+	if len(typeNames) == 0 {
+		for _, name := range g.order {
+			if st, ok := g.types[name].Type.(*ast.StructType); ok && g.hasSafeTags(st, make(map[string]bool)) {
+				typeNames = append(typeNames, name)
+			}
+		}
+		if len(typeNames) == 0 {
+			return nil, fmt.Errorf("no struct with `safe` tags found in %s", dir)
+		}
+	}
+
+	for i, name := range typeNames {
+		typeNames[i] = strings.TrimSpace(name)
+		g.generated[typeNames[i]] = true
+	}
+
+	for _, name := range typeNames {
+		if err := g.generateType(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.source()
+}
+
+func (g *generator) parseDir(dir, outputPath string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || sameFile(path, outputPath) {
+			continue
+		}
+
+		file, err := parser.ParseFile(g.fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+
+		if g.pkgName != "" && g.pkgName != file.Name.Name {
+			return fmt.Errorf("multiple packages in %s: %s and %s", dir, g.pkgName, file.Name.Name)
+		}
+		g.pkgName = file.Name.Name
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				g.types[ts.Name.Name] = ts
+				g.order = append(g.order, ts.Name.Name)
+			}
+		}
+	}
+
+	if g.pkgName == "" {
+		return fmt.Errorf("no Go files found in %s", dir)
+	}
+
+	return nil
+}
+
+func sameFile(a, b string) bool {
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// Tells if a struct has `safe` tags, be it in its own fields or in the structs it holds.
+func (g *generator) hasSafeTags(st *ast.StructType, visited map[string]bool) bool {
+	for _, field := range st.Fields.List {
+		if _, ok := lookupTag(field, "safe"); ok {
+			return true
+		}
+		if ignoredByJSON(field) {
+			continue
+		}
+
+		name, _ := g.nestedType(field.Type)
+		if name == "" || visited[name] {
+			continue
+		}
+		visited[name] = true
+		if g.hasSafeTags(g.types[name].Type.(*ast.StructType), visited) {
+			return true
+		}
+	}
+	return false
+}
+
+type nestedKind int
+
+const (
+	notNested nestedKind = iota
+	nestedStruct
+	nestedList
+)
+
+// Returns the name of the struct declared in the package that a field holds, either directly or as a list.
+func (g *generator) nestedType(typ ast.Expr) (string, nestedKind) {
+	kind := nestedStruct
+	if array, ok := typ.(*ast.ArrayType); ok {
+		typ = array.Elt
+		kind = nestedList
+	}
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return "", notNested
+	}
+	ts, ok := g.types[ident.Name]
+	if !ok || ts.TypeParams != nil {
+		return "", notNested
+	}
+	if _, ok := ts.Type.(*ast.StructType); !ok {
+		return "", notNested
+	}
+
+	return ident.Name, kind
+}
+
+func lookupTag(field *ast.Field, key string) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+	return reflect.StructTag(tag).Lookup(key)
+}
+
+func (g *generator) generateType(name string) error {
+	ts, ok := g.types[name]
+	if !ok {
+		return fmt.Errorf("type %s not found", name)
+	}
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		return fmt.Errorf("type %s is not a struct", name)
+	}
+	if ts.TypeParams != nil {
+		return fmt.Errorf("type %s: generic types are not supported", name)
+	}
+
+	recv := receiverName(name)
+	var prelude, entries bytes.Buffer
+	embeddedCount := 0
+
+	if err := g.structFields(name, st, recv, &prelude, &entries, &embeddedCount); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&g.buf, "func (%s *%s) SafeFields() safe.Fields {\n", recv, name)
+	fmt.Fprintf(&g.buf, "if %s == nil {\nreturn nil\n}\n", recv)
+	g.buf.Write(prelude.Bytes())
+	fmt.Fprintf(&g.buf, "return safe.Fields{\n")
+	g.buf.Write(entries.Bytes())
+	fmt.Fprintf(&g.buf, "}\n}\n\n")
+
+	return nil
+}
+
+// Writes the field entries of a struct, accessed through expr. Embedded structs are promoted.
+func (g *generator) structFields(typeName string, st *ast.StructType, expr string, prelude, entries *bytes.Buffer, embeddedCount *int) error {
+	for _, field := range st.Fields.List {
+		if ignoredByJSON(field) {
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(tagValue(field, "json"), ",")
+		if jsonName == "-" {
+			jsonName = ""
+		}
+
+		if len(field.Names) == 0 && jsonName == "" {
+			if embedded, isPointer, ok := g.localStruct(field.Type); ok {
+				embeddedName := typeIdent(field.Type)
+				embeddedExpr := expr + "." + embeddedName
+				if isPointer {
+					local := fmt.Sprintf("embedded%d", *embeddedCount)
+					*embeddedCount++
+					fmt.Fprintf(prelude, "%s := %s\nif %s == nil {\n%s = &%s{}\n}\n", local, embeddedExpr, local, local, embeddedName)
+					embeddedExpr = local
+				}
+				if err := g.structFields(embeddedName, embedded, embeddedExpr, prelude, entries, embeddedCount); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		tag, hasTag := lookupTag(field, "safe")
+		nestedName, nested := g.nestedType(field.Type)
+		if nested != notNested && !g.hasSafeTags(g.types[nestedName].Type.(*ast.StructType), map[string]bool{nestedName: true}) {
+			nested = notNested
+		}
+		if !hasTag && nested == notNested {
+			continue
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(typeIdent(field.Type))}
+		}
+
+		for _, ident := range names {
+			if !ident.IsExported() {
+				continue
+			}
+
+			rules, err := g.rules(tag, field.Type)
+			if err != nil {
+				return fmt.Errorf("%s: field %s.%s: %v", g.fset.Position(field.Pos()), typeName, ident.Name, err)
+			}
+
+			key := jsonName
+			if key == "" {
+				key = ident.Name
+			}
+
+			fieldExpr := expr + "." + ident.Name
+			valueExpr := g.valueExpr(fieldExpr, field.Type)
+			if nested != notNested {
+				if !g.generated[nestedName] {
+					return fmt.Errorf("%s: field %s.%s: type %s must be generated as well", g.fset.Position(field.Pos()), typeName, ident.Name, nestedName)
+				}
+				valueExpr = fieldExpr
+			}
+
+			fmt.Fprintf(entries, "{\nName: %s,\n", strconv.Quote(key))
+			if label := tagValue(field, "label"); label != "" {
+				fmt.Fprintf(entries, "Label: %s,\n", strconv.Quote(label))
+			}
+			fmt.Fprintf(entries, "Value: %s,\n", valueExpr)
+			if len(rules) > 0 {
+				fmt.Fprintf(entries, "Rules: safe.Rules{%s},\n", strings.Join(rules, ", "))
+			}
+
+			switch nested {
+			case nestedStruct:
+				fmt.Fprintf(entries, "Children: %s.SafeFields(),\n", fieldExpr)
+			case nestedList:
+				fmt.Fprintf(entries, "Elements: func() []safe.Fields {\nsafegenElements := make([]safe.Fields, len(%s))\nfor safegenIndex := range safegenElements {\nsafegenElements[safegenIndex] = %s[safegenIndex].SafeFields()\n}\nreturn safegenElements\n}(),\n", fieldExpr, fieldExpr)
+			}
+			fmt.Fprintf(entries, "},\n")
+		}
+	}
+
+	return nil
+}
+
+// Nil pointers are validated as the zero value of the type they point to, just like safe.StructFields does.
+func (g *generator) valueExpr(expr string, typ ast.Expr) string {
+	star, ok := typ.(*ast.StarExpr)
+	if !ok {
+		return expr
+	}
+	return fmt.Sprintf("func() (safegenValue %s) {\nif %s != nil {\nsafegenValue = *%s\n}\nreturn safegenValue\n}()", g.exprString(star.X), expr, expr)
+}
+
+// Returns the code of each rule in a `safe` tag.
+func (g *generator) rules(tag string, typ ast.Expr) ([]string, error) {
+	if tag == "" {
+		return nil, nil
+	}
+
+	tagRules, err := safe.ParseTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	code := make([]string, 0, len(tagRules))
+	for _, rule := range tagRules {
+		if constructor, ok := simpleRules[rule.Name]; ok {
+			code = append(code, "safe."+constructor+"()")
+			continue
+		}
+
+		switch rule.Name {
+		case "min", "max":
+			n, err := strconv.Atoi(rule.Param)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %v", rule.Name, err)
+			}
+			constructor := "Min"
+			if rule.Name == "max" {
+				constructor = "Max"
+			}
+			code = append(code, "safe."+constructor+"("+strconv.Itoa(n)+")")
+		case "oneof", "notoneof":
+			list, err := g.listLiteral(rule.Param, typ)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %v", rule.Name, err)
+			}
+			constructor := "OneOf"
+			if rule.Name == "notoneof" {
+				constructor = "NotOneOf"
+			}
+			code = append(code, "safe."+constructor+"("+list+")")
+		case "match":
+			code = append(code, "safe.Match("+g.regexVar(rule.Param)+")")
+		default:
+			return nil, fmt.Errorf("rule %q is not supported by safegen", rule.Name)
+		}
+	}
+
+	return code, nil
+}
+
+// Builds a slice literal like []string{"a", "b"} out of a list parameter like "a|b".
+func (g *generator) listLiteral(param string, typ ast.Expr) (string, error) {
+	typeName := g.exprString(typ)
+	basic, ok := g.underlyingBasic(typ)
+	if !ok {
+		return "", fmt.Errorf("fields of type %s are not supported", typeName)
+	}
+
+	parts := strings.Split(param, "|")
+	vals := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		switch {
+		case basic == "string":
+			vals = append(vals, strconv.Quote(part))
+		case basic == "bool":
+			b, err := strconv.ParseBool(part)
+			if err != nil {
+				return "", fmt.Errorf("%q is not a bool", part)
+			}
+			vals = append(vals, strconv.FormatBool(b))
+		case strings.HasPrefix(basic, "int"):
+			n, err := strconv.ParseInt(part, 10, bitSize(basic, "int"))
+			if err != nil {
+				return "", fmt.Errorf("%q is not a valid %s", part, typeName)
+			}
+			vals = append(vals, strconv.FormatInt(n, 10))
+		case strings.HasPrefix(basic, "uint"):
+			n, err := strconv.ParseUint(part, 10, bitSize(basic, "uint"))
+			if err != nil {
+				return "", fmt.Errorf("%q is not a valid %s", part, typeName)
+			}
+			vals = append(vals, strconv.FormatUint(n, 10))
+		case strings.HasPrefix(basic, "float"):
+			f, err := strconv.ParseFloat(part, bitSize(basic, "float"))
+			if err != nil {
+				return "", fmt.Errorf("%q is not a valid %s", part, typeName)
+			}
+			vals = append(vals, strconv.FormatFloat(f, 'g', -1, 64))
+		default:
+			return "", fmt.Errorf("fields of type %s are not supported", typeName)
+		}
+	}
+
+	return "[]" + typeName + "{" + strings.Join(vals, ", ") + "}", nil
+}
+
+func bitSize(basic, prefix string) int {
+	size, err := strconv.Atoi(strings.TrimPrefix(basic, prefix))
+	if err != nil {
+		return 64
+	}
+	return size
+}
+
+// Resolves the basic type behind an identifier, following type definitions declared in the package.
+func (g *generator) underlyingBasic(typ ast.Expr) (string, bool) {
+	for range 10 {
+		ident, ok := typ.(*ast.Ident)
+		if !ok {
+			return "", false
+		}
+		ts, ok := g.types[ident.Name]
+		if !ok {
+			switch ident.Name {
+			case "byte":
+				return "uint8", true
+			case "rune":
+				return "int32", true
+			}
+			return ident.Name, true
+		}
+		typ = ts.Type
+	}
+	return "", false
+}
+
+// Returns the struct declared in the package that a field embeds, if any.
+func (g *generator) localStruct(typ ast.Expr) (*ast.StructType, bool, bool) {
+	isPointer := false
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+		isPointer = true
+	}
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return nil, false, false
+	}
+	ts, ok := g.types[ident.Name]
+	if !ok {
+		return nil, false, false
+	}
+	st, ok := ts.Type.(*ast.StructType)
+	return st, isPointer, ok
+}
+
+// Declares a package level regex for a match rule, so that it is compiled only once.
+func (g *generator) regexVar(pattern string) string {
+	g.usesRegexp = true
+	name := fmt.Sprintf("safegenRegex%d", strings.Count(g.regexes.String(), "\n"))
+	literal := strconv.Quote(pattern)
+	if strconv.CanBackquote(pattern) {
+		literal = "`" + pattern + "`"
+	}
+	fmt.Fprintf(&g.regexes, "var %s = regexp.MustCompile(%s)\n", name, literal)
+	return name
+}
+
+func (g *generator) exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	format.Node(&buf, g.fset, expr)
+	return buf.String()
+}
+
+func (g *generator) source() ([]byte, error) {
+	var src bytes.Buffer
+
+	fmt.Fprintf(&src, "// Code generated by safegen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", g.pkgName)
+	fmt.Fprintf(&src, "import (\n")
+	if g.usesRegexp {
+		fmt.Fprintf(&src, "\"regexp\"\n\n")
+	}
+	fmt.Fprintf(&src, "\"github.com/cayo-rodrigues/safe\"\n")
+	fmt.Fprintf(&src, ")\n\n")
+	src.Write(g.regexes.Bytes())
+	src.WriteString("\n")
+	src.Write(g.buf.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, src.String())
+	}
+	return formatted, nil
+}
+
+// Tells if a field is ignored by encoding/json, with `json:"-"`, and has no `safe` tag of its own,
+// just like safe.StructFields does.
+func ignoredByJSON(field *ast.Field) bool {
+	_, hasTag := lookupTag(field, "safe")
+	return tagValue(field, "json") == "-" && !hasTag
+}
+
+func tagValue(field *ast.Field, key string) string {
+	val, _ := lookupTag(field, key)
+	return val
+}
+
+func typeIdent(typ ast.Expr) string {
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		return typ.Name
+	case *ast.SelectorExpr:
+		return typ.Sel.Name
+	}
+	return ""
+}
+
+func receiverName(typeName string) string {
+	for _, r := range typeName {
+		return string(unicode.ToLower(r))
+	}
+	return "v"
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSource(t *testing.T, src string) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "user.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestGenerate(t *testing.T) {
+	dir := writeSource(t, `package users
+
+type Role string
+
+type User struct {
+	Email    string  `+"`json:\"email,omitempty\" label:\"E-mail\" safe:\"required,email,max=128\"`"+`
+	Role     Role    `+"`json:\"role\" safe:\"oneof=admin|guest\"`"+`
+	Level    uint8   `+"`safe:\"notoneof=010|13\"`"+`
+	Nickname *string `+"`json:\"nickname\" safe:\"min=3\"`"+`
+	Code     string  `+"`safe:\"match=^\\\\d{2\\\\,4}$\"`"+`
+	Secret   string  `+"`json:\"-\"`"+`
+	Token    string  `+"`json:\"-\" safe:\"required\"`"+`
+	internal string  `+"`safe:\"required\"`"+`
+	Ignored  string
+}
+`)
+
+	src, err := generate(dir, nil, filepath.Join(dir, "safefields.go"))
+	if err != nil {
+		t.Fatalf("generation should succeed. Got: %v", err)
+	}
+
+	expectedSnippets := []string{
+		"// Code generated by safegen; DO NOT EDIT.",
+		"package users",
+		"var safegenRegex0 = regexp.MustCompile(`^\\d{2,4}$`)",
+		"func (u *User) SafeFields() safe.Fields {",
+		`Name:  "email",`,
+		`Label: "E-mail",`,
+		"Rules: safe.Rules{safe.Required(), safe.Email(), safe.Max(128)},",
+		`Rules: safe.Rules{safe.OneOf([]Role{"admin", "guest"})},`,
+		`Name:  "Level",`,
+		"Rules: safe.Rules{safe.NotOneOf([]uint8{10, 13})},",
+		"safegenValue = *u.Nickname",
+		"Rules: safe.Rules{safe.Match(safegenRegex0)},",
+		`Name:  "Token",`,
+	}
+	for _, snippet := range expectedSnippets {
+		if !strings.Contains(string(src), snippet) {
+			t.Errorf("generated code should contain %q.\n%s", snippet, src)
+		}
+	}
+
+	for _, snippet := range []string{"internal", "Ignored", "Secret"} {
+		if strings.Contains(string(src), snippet) {
+			t.Errorf("generated code should not contain %q.\n%s", snippet, src)
+		}
+	}
+}
+
+func TestGenerateInvalidTags(t *testing.T) {
+	invalidFields := []string{
+		"Name string `safe:\"pepsiman\"`",
+		"Name string `safe:\"max=many\"`",
+		"Name string `safe:\"required=true\"`",
+		"Name string `safe:\"match=(\"`",
+		"Age int `safe:\"oneof=1|two\"`",
+		"Tags []string `safe:\"oneof=a|b\"`",
+	}
+
+	for _, field := range invalidFields {
+		dir := writeSource(t, "package users\n\ntype User struct {\n"+field+"\n}\n")
+
+		if _, err := generate(dir, []string{"User"}, filepath.Join(dir, "user_safefields.go")); err == nil {
+			t.Errorf("generation should fail for field %s", field)
+		} else if !strings.Contains(err.Error(), "user.go:4") {
+			t.Errorf("error should point to the field position. Got: %v", err)
+		}
+	}
+
+	dir := writeSource(t, "package users\n\ntype User struct{}\n")
+	if _, err := generate(dir, []string{"Pepsiman"}, filepath.Join(dir, "safefields.go")); err == nil {
+		t.Errorf("generation should fail for unknown types")
+	}
+}
+
+func TestGenerateReceiverNames(t *testing.T) {
+	dir := writeSource(t, `package vehicles
+
+type Vehicle struct {
+	Plate *string `+"`safe:\"max=8\"`"+`
+}
+
+type Item struct {
+	Sku   string `+"`safe:\"required\"`"+`
+	Items []Item
+}
+`)
+
+	src, err := generate(dir, nil, filepath.Join(dir, "safefields.go"))
+	if err != nil {
+		t.Fatalf("generation should succeed. Got: %v", err)
+	}
+
+	for _, snippet := range []string{
+		"func (v *Vehicle) SafeFields() safe.Fields {",
+		"if v.Plate != nil {",
+		"safegenValue = *v.Plate",
+		"func (i *Item) SafeFields() safe.Fields {",
+		"safegenElements[safegenIndex] = i.Items[safegenIndex].SafeFields()",
+	} {
+		if !strings.Contains(string(src), snippet) {
+			t.Errorf("generated code should contain %q.\n%s", snippet, src)
+		}
+	}
+
+	for _, snippet := range []string{"func() (v string)", "v = *v.Plate"} {
+		if strings.Contains(string(src), snippet) {
+			t.Errorf("generated code should not shadow the receiver with %q.\n%s", snippet, src)
+		}
+	}
+}
+
+func TestGenerateNested(t *testing.T) {
+	dir := writeSource(t, `package users
+
+type Item struct {
+	Sku string `+"`safe:\"required\"`"+`
+}
+
+type Order struct {
+	Items []Item `+"`json:\"items\"`"+`
+}
+`)
+
+	src, err := generate(dir, nil, filepath.Join(dir, "safefields.go"))
+	if err != nil {
+		t.Fatalf("generation should succeed. Got: %v", err)
+	}
+
+	for _, snippet := range []string{"func (i *Item) SafeFields() safe.Fields {", "safegenElements[safegenIndex] = o.Items[safegenIndex].SafeFields()"} {
+		if !strings.Contains(string(src), snippet) {
+			t.Errorf("generated code should contain %q.\n%s", snippet, src)
+		}
+	}
+
+	if _, err := generate(dir, []string{"Order"}, filepath.Join(dir, "order_safefields.go")); err == nil {
+		t.Errorf("generation should fail when a nested type is not generated")
+	}
+}
@@ -0,0 +1,86 @@
+// Safegen generates SafeFields methods out of `safe` struct tags, so that structs
+// can be validated without reflection.
+//
+// Usage:
+//
+//	//go:generate go run github.com/cayo-rodrigues/safe/cmd/safegen -type User,Address
+//
+// Given a struct like:
+//
+//	type User struct {
+//		Email string `json:"email" safe:"required,email,max=128"`
+//	}
+//
+// safegen writes a file with the following method:
+//
+//	func (u *User) SafeFields() safe.Fields {
+//		if u == nil {
+//			return nil
+//		}
+//		return safe.Fields{
+//			{
+//				Name:  "email",
+//				Value: u.Email,
+//				Rules: safe.Rules{safe.Required(), safe.Email(), safe.Max(128)},
+//			},
+//		}
+//	}
+//
+// The tag syntax is the same as the one accepted by safe.ValidateStruct (please refer to safe.ParseTag),
+// and the generated fields are the same as the ones built by safe.StructFields.
+// Unknown rules and bad parameters make safegen fail, instead of surprising you at runtime.
+//
+// Fields holding other structs of the package become Field.Children, and slices of them become Field.Elements.
+// Those structs must be generated as well.
+//
+// Flags:
+//
+//	-type    comma-separated list of struct names; defaults to every struct with `safe` tags
+//	-output  output file name; defaults to <type>_safefields.go, or safefields.go when -type is not given
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("safegen: ")
+
+	typeNames := flag.String("type", "", "comma-separated list of struct names; defaults to every struct with `safe` tags")
+	output := flag.String("output", "", "output file name; defaults to <type>_safefields.go")
+	flag.Parse()
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	var types []string
+	if *typeNames != "" {
+		types = strings.Split(*typeNames, ",")
+	}
+
+	outputName := *output
+	if outputName == "" {
+		outputName = "safefields.go"
+		if len(types) > 0 {
+			outputName = strings.ToLower(types[0]) + "_safefields.go"
+		}
+	}
+	outputPath := filepath.Join(dir, outputName)
+
+	src, err := generate(dir, types, outputPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(outputPath, src, 0o644); err != nil {
+		log.Fatal(fmt.Errorf("writing output: %w", err))
+	}
+}
@@ -0,0 +1,289 @@
+package safe
+
+import (
+	"slices"
+	"strings"
+)
+
+// Computes the two check digits of a cpf, given its first 9 digits.
+//
+// In case base is not made of exactly 9 digits, returns an empty string.
+//
+// Example usage:
+//
+//	safe.CpfCheckDigits("393546320") // "09"
+func CpfCheckDigits(base string) string {
+	digits, ok := parseDigits(base)
+	if !ok || len(digits) != 9 {
+		return ""
+	}
+
+	first := cpfCheckDigit(digits)
+	second := cpfCheckDigit(append(digits, first))
+
+	return string(rune('0'+first)) + string(rune('0'+second))
+}
+
+// A helper function to determine if a cpf is valid, with or without symbols (.-).
+//
+// Besides the format, both check digits are verified, and sequences of a single repeated digit,
+// like "111.111.111-11", are rejected.
+func IsValidCpf(cpf string) bool {
+	if !CpfRegex.MatchString(cpf) {
+		return false
+	}
+
+	digits := stripSymbols(cpf, ".-")
+	if isRepeatedDigit(digits) {
+		return false
+	}
+
+	return CpfCheckDigits(digits[:9]) == digits[9:]
+}
+
+// Computes the two check digits of a cnpj, given its first 12 characters.
+//
+// Both numeric and alphanumeric cnpjs are supported. Each character weighs its ASCII code minus 48,
+// so digits keep their values and letters go from 17 ("A") to 42 ("Z").
+//
+// In case base is not made of exactly 12 digits or uppercase letters, returns an empty string.
+//
+// Example usage:
+//
+//	safe.CnpjCheckDigits("12ABC34501DE") // "35"
+func CnpjCheckDigits(base string) string {
+	if len(base) != 12 {
+		return ""
+	}
+
+	values := make([]int, 0, 13)
+	for _, r := range base {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') {
+			return ""
+		}
+		values = append(values, int(r-'0'))
+	}
+
+	first := mod11CycleCheckDigit(values)
+	second := mod11CycleCheckDigit(append(values, first))
+
+	return string(rune('0'+first)) + string(rune('0'+second))
+}
+
+// A helper function to determine if a cnpj is valid, with or without symbols (./-).
+//
+// Both the classic numeric format and the alphanumeric one, with letters in the first 12 positions,
+// are accepted. Letters may be lowercase. Besides the format, both check digits are verified, and
+// sequences of a single repeated digit, like "00.000.000/0000-00", are rejected.
+func IsValidCnpj(cnpj string) bool {
+	if !CnpjRegex.MatchString(cnpj) {
+		return false
+	}
+
+	chars := strings.ToUpper(stripSymbols(cnpj, "./-"))
+	if isRepeatedDigit(chars) {
+		return false
+	}
+
+	return CnpjCheckDigits(chars[:12]) == chars[12:]
+}
+
+// The mod 11 check digit of a cnpj (and many others), weighting values from right to left with 2 to 9, over and over.
+func mod11CycleCheckDigit(values []int) int {
+	sum := 0
+	weight := 2
+	for i := len(values) - 1; i >= 0; i-- {
+		sum += values[i] * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	rest := sum % 11
+	if rest < 2 {
+		return 0
+	}
+	return 11 - rest
+}
+
+// The mod 11 check digit of a cpf, weighting digits from len(digits)+1 down to 2.
+func cpfCheckDigit(digits []int) int {
+	sum := 0
+	weight := len(digits) + 1
+	for _, d := range digits {
+		sum += d * weight
+		weight--
+	}
+
+	rest := sum % 11
+	if rest < 2 {
+		return 0
+	}
+	return 11 - rest
+}
+
+// Converts a string of digits into ints. The second result is false if there is anything but digits.
+func parseDigits(str string) ([]int, bool) {
+	digits := make([]int, 0, len(str))
+	for _, r := range str {
+		if r < '0' || r > '9' {
+			return nil, false
+		}
+		digits = append(digits, int(r-'0'))
+	}
+	return digits, true
+}
+
+// Removes every occurrence of the given symbols from str.
+func stripSymbols(str, symbols string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(symbols, r) {
+			return -1
+		}
+		return r
+	}, str)
+}
+
+// Tells if str is made of a single repeated character, like "00000000000".
+func isRepeatedDigit(str string) bool {
+	return str != "" && strings.Count(str, str[:1]) == len(str)
+}
+
+// A helper function to determine if a PIS, PASEP or NIS is valid, with or without symbols (.-).
+//
+// Besides the format, the check digit is verified, and sequences of a single repeated digit are rejected.
+func IsValidPis(pis string) bool {
+	if !PisRegex.MatchString(pis) {
+		return false
+	}
+
+	pis = stripSymbols(pis, ".-")
+	if isRepeatedDigit(pis) {
+		return false
+	}
+
+	digits, _ := parseDigits(pis)
+
+	checkDigit := 11 - weightedSum(digits[:10], []int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2})%11
+	if checkDigit >= 10 {
+		checkDigit = 0
+	}
+	return digits[10] == checkDigit
+}
+
+// The codes of each state (uf) in a título de eleitor. "ZZ" stands for voters living abroad.
+var tituloEleitorUFs = map[string]string{
+	"01": "SP", "02": "MG", "03": "RJ", "04": "RS", "05": "BA", "06": "PR", "07": "CE",
+	"08": "PE", "09": "SC", "10": "GO", "11": "MA", "12": "PB", "13": "PA", "14": "ES",
+	"15": "PI", "16": "RN", "17": "AL", "18": "MT", "19": "MS", "20": "DF", "21": "SE",
+	"22": "AM", "23": "RO", "24": "AC", "25": "AP", "26": "RR", "27": "TO", "28": "ZZ",
+}
+
+// A helper function to find out the state (uf) of a título de eleitor, with or without whitespaces,
+// like "SC" for "0043 5687 0906".
+//
+// "ZZ" is returned for voters living abroad.
+//
+// The second result is false when the título de eleitor is not valid. Both check digits are verified.
+func TituloEleitorUF(titulo string) (string, bool) {
+	if !TituloEleitorRegex.MatchString(titulo) {
+		return "", false
+	}
+
+	titulo = stripSymbols(titulo, " ")
+	digits, _ := parseDigits(titulo)
+	ufCode := titulo[8:10]
+	uf, ok := tituloEleitorUFs[ufCode]
+	if !ok {
+		return "", false
+	}
+
+	// in São Paulo and Minas Gerais, remainders of 0 give check digits 1
+	checkDigit := func(sum int) int {
+		rest := sum % 11
+		if rest == 10 {
+			rest = 0
+		}
+		if rest == 0 && (ufCode == "01" || ufCode == "02") {
+			rest = 1
+		}
+		return rest
+	}
+
+	first := checkDigit(weightedSum(digits[:8], []int{2, 3, 4, 5, 6, 7, 8, 9}))
+	second := checkDigit(weightedSum([]int{digits[8], digits[9], first}, []int{7, 8, 9}))
+	if digits[10] != first || digits[11] != second {
+		return "", false
+	}
+
+	return uf, true
+}
+
+// A helper function to determine if a título de eleitor is valid, with or without whitespaces.
+//
+// Please refer to safe.TituloEleitorUF.
+func IsValidTituloEleitor(titulo string) bool {
+	_, ok := TituloEleitorUF(titulo)
+	return ok
+}
+
+// A helper function to determine if a CNH (carteira nacional de habilitação) is valid, with 11 digits.
+//
+// Besides the format, both check digits are verified, and sequences of a single repeated digit are rejected.
+func IsValidCnh(cnh string) bool {
+	if !CnhRegex.MatchString(cnh) || isRepeatedDigit(cnh) {
+		return false
+	}
+
+	digits, _ := parseDigits(cnh)
+
+	// when the first check digit would be 10 or more, it becomes 0, and the second one is discounted by 2
+	discount := 0
+	first := weightedSum(digits[:9], descendingWeights(9, 9)) % 11
+	if first >= 10 {
+		first, discount = 0, 2
+	}
+
+	second := weightedSum(digits[:9], []int{1, 2, 3, 4, 5, 6, 7, 8, 9}) % 11
+	if second >= 10 {
+		second = 0
+	} else {
+		second -= discount
+	}
+
+	return digits[9] == first && digits[10] == second
+}
+
+// A helper function to determine if a CNS (cartão nacional de saúde) is valid, with or without whitespaces.
+//
+// Definitive numbers, starting with 1 or 2, are made of a PIS followed by its check digits, while provisional
+// ones, starting with 7, 8 or 9, must have a weighted sum that is a multiple of 11.
+func IsValidCns(cns string) bool {
+	if !CnsRegex.MatchString(cns) {
+		return false
+	}
+
+	digits, _ := parseDigits(stripSymbols(cns, " "))
+	weights := descendingWeights(15, 15)
+
+	switch digits[0] {
+	case 1, 2:
+		sum := weightedSum(digits[:11], weights)
+		suffix := []int{0, 0, 0}
+		checkDigit := 11 - sum%11
+		if checkDigit == 11 {
+			checkDigit = 0
+		}
+		if checkDigit == 10 {
+			suffix = []int{0, 0, 1}
+			checkDigit = 11 - (sum+2)%11
+		}
+		expected := append(append(append([]int{}, digits[:11]...), suffix...), checkDigit)
+		return slices.Equal(expected, digits)
+	case 7, 8, 9:
+		return weightedSum(digits, weights)%11 == 0
+	}
+
+	return false
+}
@@ -0,0 +1,163 @@
+package safe
+
+import (
+	"strings"
+)
+
+// Stable codes for each kind of broken rule.
+//
+// Unlike messages, they never change, so API clients may rely on them.
+const (
+	InvalidCode           = "invalid"
+	RequiredCode          = "required"
+	MustBeTrueCode        = "must_be_true"
+	MustBeFalseCode       = "must_be_false"
+	InvalidFormatCode     = "invalid_format"
+	WeakPasswordCode      = "weak_password"
+	UniqueListCode        = "unique_list"
+	MinValueCode          = "min_value"
+	MinCharsCode          = "min_chars"
+	MaxValueCode          = "max_value"
+	MaxCharsCode          = "max_chars"
+	UnacceptableValueCode = "unacceptable_value"
+	AfterCode             = "after"
+	NotAfterCode          = "not_after"
+	BeforeCode            = "before"
+	NotBeforeCode         = "not_before"
+	MaxDaysRangeCode      = "max_days_range"
+	CepNotInStateCode     = "cep_not_in_state"
+	CardExpiredCode       = "card_expired"
+
+	// numeric bounds, steps and scales
+	BetweenCode            = "between"
+	BetweenExclusiveCode   = "between_exclusive"
+	GreaterThanCode        = "greater_than"
+	GreaterThanOrEqualCode = "greater_than_or_equal"
+	LessThanCode           = "less_than"
+	LessThanOrEqualCode    = "less_than_or_equal"
+	MultipleOfCode         = "multiple_of"
+	MaxDecimalPlacesCode   = "max_decimal_places"
+)
+
+// Describes a single broken rule.
+type ValidationError struct {
+	// The name of the field, or its path in case of nested fields, as in "address.cep"
+	Field string `json:"field"`
+	// The RuleName of the broken RuleSet, as in "safe.Max"
+	Rule string `json:"rule"`
+	// A stable code for the broken rule, as in "max_chars"
+	Code    string         `json:"code"`
+	Params  map[string]any `json:"params,omitempty"`
+	Message string         `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Every broken rule of a validation, in the same order as the Fields and their Rules.
+//
+// It is returned as an error by safe.Check and safe.CheckAll, and can be retrieved with errors.As:
+//
+//	err := safe.Check(fields)
+//
+//	var validationErrs safe.ValidationErrors
+//	if errors.As(err, &validationErrs) {
+//		for _, e := range validationErrs {
+//			fmt.Println(e.Field, e.Code, e.Params, e.Message)
+//		}
+//	}
+//
+// Individual errors can be retrieved as well, with errors.As and a *safe.ValidationError.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Lets errors.As and errors.Is look into each ValidationError.
+func (errs ValidationErrors) Unwrap() []error {
+	unwrapped := make([]error, 0, len(errs))
+	for _, e := range errs {
+		unwrapped = append(unwrapped, e)
+	}
+	return unwrapped
+}
+
+// Returns the first message of each field, just like safe.Validate does.
+//
+// In case there are no errors, returns nil.
+func (errs ValidationErrors) ErrorMessages() ErrorMessages {
+	var messages ErrorMessages
+	for _, e := range errs {
+		if messages == nil {
+			messages = make(ErrorMessages)
+		}
+		if _, exists := messages[e.Field]; !exists {
+			messages[e.Field] = e.Message
+		}
+	}
+	return messages
+}
+
+// Returns all messages of each field, just like safe.ValidateAll does.
+//
+// In case there are no errors, returns nil.
+func (errs ValidationErrors) AllErrorMessages() AllErrorMessages {
+	var messages AllErrorMessages
+	for _, e := range errs {
+		if messages == nil {
+			messages = make(AllErrorMessages)
+		}
+		messages[e.Field] = append(messages[e.Field], e.Message)
+	}
+	return messages
+}
+
+// Validates fields just like safe.Validate, but returns the broken rules as safe.ValidationErrors.
+//
+// In case all fields are valid, returns nil.
+//
+// Example usage:
+//
+//	if err := safe.Check(fields); err != nil {
+//		return err
+//	}
+func Check(fields Fields) error {
+	return collectErrors(fields, validation{})
+}
+
+// Validates fields just like safe.ValidateAll, but returns the broken rules as safe.ValidationErrors.
+//
+// In case all fields are valid, returns nil.
+func CheckAll(fields Fields) error {
+	return collectErrors(fields, validation{all: true})
+}
+
+func collectErrors(fields Fields, v validation) error {
+	errs := validationErrors(fields, v)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func validationErrors(fields Fields, v validation) ValidationErrors {
+	var errs ValidationErrors
+
+	validateFields(fields, "", v, func(path string, rs *RuleSet) {
+		errs = append(errs, &ValidationError{
+			Field:   path,
+			Rule:    rs.RuleName,
+			Code:    rs.Code(),
+			Params:  rs.Params,
+			Message: rs.MessageFunc(rs),
+		})
+	})
+
+	return errs
+}
@@ -1,6 +1,8 @@
 package safe
 
 import (
+	"encoding/json"
+	"reflect"
 	"regexp"
 	"time"
 	"unicode/utf8"
@@ -14,10 +16,10 @@ import (
 //		{
 //			Name:  "Ie",
 //			Value: e.Ie,
-//			Rules: safe.Rules(
-//				safe.Match(safe.IEMGRegex),
+//			Rules: safe.Rules{
+//				safe.InscricaoEstadual("MG"),
 //				safe.RequiredUnless(safe.All(e.PostalCode, e.Neighborhood, e.StreetType, e.StreetName, e.Number)),
-//			),
+//			},
 //		},
 //	}
 //
@@ -46,32 +48,49 @@ func All(vals ...any) bool {
 //
 //	string: it must have more than one rune (or character, if you will).
 //
-//	int, float64, float32: it must not be zero.
+//	numbers of any kind, including named numeric types, json.Number, *big.Int and *big.Rat: it must not be zero.
 //
 //	time.Time: it must not be the zero time instant, as prescribed by time.Time.IsZero.
 //
 //	struct{}: empty structs are not considered as "having a value"
 //
-//	anything else: is not nil
+//	anything else: is not nil, and is not a nil pointer, slice or map either. Note that empty slices and maps
+//	that are not nil, like []string{}, do have a value. Before nested fields were supported, only nil itself
+//	was considered as "not having a value".
 func HasValue(val any) bool {
 	switch val := val.(type) {
 	case bool:
 		return val
 	case string:
 		return utf8.RuneCountInString(val) > 0
-	case int:
-		return val != 0
-	case float64:
-		return val != 0
-	case float32:
-		return val != 0
 	case time.Time:
 		return !val.IsZero()
 	case struct{}:
 		return false
+	case json.Number:
+		n, ok := toRat(val)
+		return ok && n.Sign() != 0
 	default:
-		return val != nil
+		if n, ok := toRat(val); ok {
+			return n.Sign() != 0
+		}
+		return !isNil(val)
+	}
+}
+
+// Tells if val is nil, or a nil pointer, slice or map.
+func isNil(val any) bool {
+	if val == nil {
+		return true
 	}
+
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return v.IsNil()
+	}
+
+	return false
 }
 
 // Given a list of values, all of them should be unique.
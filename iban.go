@@ -0,0 +1,166 @@
+package safe
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The structure of the BBAN (basic bank account number) of each country that uses IBANs, as in the SWIFT IBAN registry.
+//
+// Each part has a length and a kind: n for digits, a for uppercase letters and c for both.
+// For instance, "8n10n" means 8 digits (the bank code) followed by 10 digits (the account number).
+var ibanStructures = map[string]string{
+	"AD": "4n4n12c", "AE": "3n16n", "AL": "8n16c", "AT": "5n11n", "AZ": "4a20c",
+	"BA": "3n3n8n2n", "BE": "3n7n2n", "BG": "4a4n2n8c", "BH": "4a14c", "BI": "5n5n11n2n", "BR": "8n5n10n1a1c", "BY": "4c4n16c",
+	"CH": "5n12c", "CR": "4n14n", "CY": "3n5n16c", "CZ": "4n6n10n",
+	"DE": "8n10n", "DJ": "5n5n11n2n", "DK": "4n9n1n", "DO": "4c20n",
+	"EE": "2n2n11n1n", "EG": "4n4n17n", "ES": "4n4n1n1n10n",
+	"FI": "3n11n", "FK": "2a12n", "FO": "4n9n1n", "FR": "5n5n11c2n",
+	"GB": "4a6n8n", "GE": "2a16n", "GI": "4a15c", "GL": "4n9n1n", "GR": "3n4n16c", "GT": "4c20c",
+	"HN": "4a20n", "HR": "7n10n", "HU": "3n4n1n15n1n",
+	"IE": "4a6n8n", "IL": "3n3n13n", "IQ": "4a3n12n", "IS": "4n2n6n10n", "IT": "1a5n5n12c",
+	"JO": "4a4n18c",
+	"KW": "4a22c", "KZ": "3n13c",
+	"LB": "4n20c", "LC": "4a24c", "LI": "5n12c", "LT": "5n11n", "LU": "3n13c", "LV": "4a13c", "LY": "3n3n15n",
+	"MC": "5n5n11c2n", "MD": "2c18c", "ME": "3n13n2n", "MK": "3n10c2n", "MN": "4n12n", "MR": "5n5n11n2n",
+	"MT": "4a5n18c", "MU": "4a2n2n12n3n3a",
+	"NI": "4a20n", "NL": "4a10n", "NO": "4n6n1n",
+	"OM": "3n16c",
+	"PK": "4a16c", "PL": "8n16n", "PS": "4a21c", "PT": "4n4n11n2n",
+	"QA": "4a21c",
+	"RO": "4a16c", "RS": "3n13n2n", "RU": "9n5n15c",
+	"SA": "2n18c", "SC": "4a2n2n16n3a", "SD": "2n12n", "SE": "3n16n1n", "SI": "5n8n2n", "SK": "4n6n10n", "SM": "1a5n5n12c",
+	"SO": "4n3n12n", "ST": "4n4n11n2n", "SV": "4a20n",
+	"TL": "3n14n2n", "TN": "2n3n13n2n", "TR": "5n1n16c",
+	"UA": "6n19c",
+	"VA": "3n15n", "VG": "4a16n",
+	"XK": "4n10n2n",
+	"YE": "4a4n18c",
+}
+
+// The ISO 3166-1 alpha-2 country codes, and XK (Kosovo), which is used by SWIFT.
+var isoCountries = map[string]bool{
+	"AD": true, "AE": true, "AF": true, "AG": true, "AI": true, "AL": true, "AM": true, "AO": true, "AQ": true, "AR": true,
+	"AS": true, "AT": true, "AU": true, "AW": true, "AX": true, "AZ": true, "BA": true, "BB": true, "BD": true, "BE": true,
+	"BF": true, "BG": true, "BH": true, "BI": true, "BJ": true, "BL": true, "BM": true, "BN": true, "BO": true, "BQ": true,
+	"BR": true, "BS": true, "BT": true, "BV": true, "BW": true, "BY": true, "BZ": true, "CA": true, "CC": true, "CD": true,
+	"CF": true, "CG": true, "CH": true, "CI": true, "CK": true, "CL": true, "CM": true, "CN": true, "CO": true, "CR": true,
+	"CU": true, "CV": true, "CW": true, "CX": true, "CY": true, "CZ": true, "DE": true, "DJ": true, "DK": true, "DM": true,
+	"DO": true, "DZ": true, "EC": true, "EE": true, "EG": true, "EH": true, "ER": true, "ES": true, "ET": true, "FI": true,
+	"FJ": true, "FK": true, "FM": true, "FO": true, "FR": true, "GA": true, "GB": true, "GD": true, "GE": true, "GF": true,
+	"GG": true, "GH": true, "GI": true, "GL": true, "GM": true, "GN": true, "GP": true, "GQ": true, "GR": true, "GS": true,
+	"GT": true, "GU": true, "GW": true, "GY": true, "HK": true, "HM": true, "HN": true, "HR": true, "HT": true, "HU": true,
+	"ID": true, "IE": true, "IL": true, "IM": true, "IN": true, "IO": true, "IQ": true, "IR": true, "IS": true, "IT": true,
+	"JE": true, "JM": true, "JO": true, "JP": true, "KE": true, "KG": true, "KH": true, "KI": true, "KM": true, "KN": true,
+	"KP": true, "KR": true, "KW": true, "KY": true, "KZ": true, "LA": true, "LB": true, "LC": true, "LI": true, "LK": true,
+	"LR": true, "LS": true, "LT": true, "LU": true, "LV": true, "LY": true, "MA": true, "MC": true, "MD": true, "ME": true,
+	"MF": true, "MG": true, "MH": true, "MK": true, "ML": true, "MM": true, "MN": true, "MO": true, "MP": true, "MQ": true,
+	"MR": true, "MS": true, "MT": true, "MU": true, "MV": true, "MW": true, "MX": true, "MY": true, "MZ": true, "NA": true,
+	"NC": true, "NE": true, "NF": true, "NG": true, "NI": true, "NL": true, "NO": true, "NP": true, "NR": true, "NU": true,
+	"NZ": true, "OM": true, "PA": true, "PE": true, "PF": true, "PG": true, "PH": true, "PK": true, "PL": true, "PM": true,
+	"PN": true, "PR": true, "PS": true, "PT": true, "PW": true, "PY": true, "QA": true, "RE": true, "RO": true, "RS": true,
+	"RU": true, "RW": true, "SA": true, "SB": true, "SC": true, "SD": true, "SE": true, "SG": true, "SH": true, "SI": true,
+	"SJ": true, "SK": true, "SL": true, "SM": true, "SN": true, "SO": true, "SR": true, "SS": true, "ST": true, "SV": true,
+	"SX": true, "SY": true, "SZ": true, "TC": true, "TD": true, "TF": true, "TG": true, "TH": true, "TJ": true, "TK": true,
+	"TL": true, "TM": true, "TN": true, "TO": true, "TR": true, "TT": true, "TV": true, "TW": true, "TZ": true, "UA": true,
+	"UG": true, "UM": true, "US": true, "UY": true, "UZ": true, "VA": true, "VC": true, "VE": true, "VG": true, "VI": true,
+	"VN": true, "VU": true, "WF": true, "WS": true, "XK": true, "YE": true, "YT": true, "ZA": true, "ZM": true, "ZW": true,
+}
+
+// A BIC has a bank code (4 letters), a country code (2 letters), a location code (2 characters)
+// and an optional branch code (3 characters).
+var bicRegex = regexp.MustCompile(`^[A-Z]{4}([A-Z]{2})[0-9A-Z]{2}(?:[0-9A-Z]{3})?$`)
+
+// A helper function to remove whitespaces and dashes (-) of an IBAN, and make it uppercase,
+// as in its electronic format, like "DE89370400440532013000".
+func NormalizeIBAN(iban string) string {
+	return strings.ToUpper(stripSymbols(iban, " -"))
+}
+
+// A helper function to print an IBAN in groups of 4 characters, as in its paper format,
+// like "DE89 3704 0044 0532 0130 00".
+//
+// It does not validate the IBAN, please refer to safe.IsValidIBAN.
+func FormatIBAN(iban string) string {
+	iban = NormalizeIBAN(iban)
+
+	groups := make([]string, 0, len(iban)/4+1)
+	for len(iban) > 4 {
+		groups = append(groups, iban[:4])
+		iban = iban[4:]
+	}
+	groups = append(groups, iban)
+
+	return strings.Join(groups, " ")
+}
+
+// A helper function to determine if an IBAN is valid, in its electronic or paper format,
+// like "DE89370400440532013000" or "DE89 3704 0044 0532 0130 00".
+//
+// Whitespaces and dashes are ignored. The country must use IBANs, and the length and the structure of the number
+// must be the ones of its country. The check digits (ISO 7064 mod 97-10) are verified.
+func IsValidIBAN(iban string) bool {
+	iban = NormalizeIBAN(iban)
+	if len(iban) < 5 {
+		return false
+	}
+
+	structure, ok := ibanStructures[iban[:2]]
+	if !ok {
+		return false
+	}
+
+	if _, ok := parseDigits(iban[2:4]); !ok {
+		return false
+	}
+
+	if !matchesIBANStructure(iban[4:], structure) {
+		return false
+	}
+
+	// the first 4 characters are moved to the end, and letters are replaced by 10 (A) to 35 (Z)
+	rest := 0
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			rest = (rest*100 + int(r-'A'+10)) % 97
+		} else {
+			rest = (rest*10 + int(r-'0')) % 97
+		}
+	}
+	return rest == 1
+}
+
+// Walks the parts of a BBAN structure, like "4a6n8n", checking the length and the kind of each one.
+func matchesIBANStructure(bban, structure string) bool {
+	length := 0
+	for i := 0; i < len(structure); i++ {
+		c := structure[i]
+		if c >= '0' && c <= '9' {
+			length = length*10 + int(c-'0')
+			continue
+		}
+
+		if len(bban) < length {
+			return false
+		}
+		for _, r := range bban[:length] {
+			isDigit := r >= '0' && r <= '9'
+			isLetter := r >= 'A' && r <= 'Z'
+			if c == 'n' && !isDigit || c == 'a' && !isLetter || c == 'c' && !isDigit && !isLetter {
+				return false
+			}
+		}
+		bban = bban[length:]
+		length = 0
+	}
+
+	return bban == ""
+}
+
+// A helper function to determine if a SWIFT/BIC code is valid, with 8 or 11 characters, like "DEUTDEFF" or "DEUTDEFF500".
+//
+// Lowercase letters are accepted. The country code must be a valid ISO 3166-1 code.
+func IsValidBIC(bic string) bool {
+	matches := bicRegex.FindStringSubmatch(strings.ToUpper(bic))
+	return matches != nil && isoCountries[matches[1]]
+}
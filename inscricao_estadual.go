@@ -0,0 +1,379 @@
+package safe
+
+import "strings"
+
+// The value accepted by safe.InscricaoEstadualOrIsento for taxpayers exempt from it.
+const IsentoIE = "ISENTO"
+
+// The inscrição estadual validators of each state, keyed by uf.
+var ieValidators = map[string]func(digits []int) bool{
+	"AC": ieAC,
+	"AL": ieAL,
+	"AM": ieStandard(),
+	"AP": ieAP,
+	"BA": ieBA,
+	"CE": ieStandard(),
+	"DF": ieDF,
+	"ES": ieStandard(),
+	"GO": ieGO,
+	"MA": ieStandard("12"),
+	"MG": ieMG,
+	"MS": ieStandard("28", "50"),
+	"MT": ieMT,
+	"PA": ieStandard("15", "75", "76", "77", "78", "79"),
+	"PB": ieStandard(),
+	"PE": iePE,
+	"PI": ieStandard(),
+	"PR": iePR,
+	"RJ": ieRJ,
+	"RN": ieRN,
+	"RO": ieRO,
+	"RR": ieRR,
+	"RS": ieRS,
+	"SC": ieStandard(),
+	"SE": ieStandard(),
+	"SP": ieSP,
+	"TO": ieTO,
+}
+
+// A helper function to determine if an inscrição estadual is valid in a given state (uf), like "MG" or "sp".
+//
+// It may or may not include symbols (./-). Each state has its own length, prefix and check digit algorithm,
+// as described by SINTEGRA. For rural producers in São Paulo, the leading "P" is expected, as in "P-01100424.3/002".
+//
+// "ISENTO" is not a valid inscrição estadual, please refer to safe.IsIsentoIE.
+func IsValidInscricaoEstadual(ie, uf string) bool {
+	uf = strings.ToUpper(strings.TrimSpace(uf))
+	validate, ok := ieValidators[uf]
+	if !ok {
+		return false
+	}
+
+	ie = strings.ToUpper(stripSymbols(strings.TrimSpace(ie), "./- "))
+	if uf == "SP" && strings.HasPrefix(ie, "P") {
+		digits, ok := parseDigits(ie[1:])
+		return ok && ieSPRural(digits)
+	}
+
+	digits, ok := parseDigits(ie)
+	if !ok || len(digits) == 0 {
+		return false
+	}
+
+	return validate(digits)
+}
+
+// A helper function to determine if an inscrição estadual is "ISENTO", case insensitive,
+// which is used by taxpayers exempt from it.
+func IsIsentoIE(ie string) bool {
+	return strings.EqualFold(strings.TrimSpace(ie), IsentoIE)
+}
+
+// The usual mod 11 check digit: 11 minus the remainder of the weighted sum, or 0 when that is 10 or 11.
+func mod11CheckDigit(digits, weights []int) int {
+	rest := weightedSum(digits, weights) % 11
+	if rest < 2 {
+		return 0
+	}
+	return 11 - rest
+}
+
+func weightedSum(digits, weights []int) int {
+	sum := 0
+	for i, d := range digits {
+		sum += d * weights[i]
+	}
+	return sum
+}
+
+// Returns n weights, going down from the first one, like 9, 8, 7, ..., 2.
+func descendingWeights(first, n int) []int {
+	weights := make([]int, n)
+	for i := range weights {
+		weights[i] = first - i
+	}
+	return weights
+}
+
+// Tells if digits start with any of the given prefixes. No prefixes means any prefix is fine.
+func hasDigitPrefix(digits []int, prefixes ...string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if len(digits) < len(prefix) {
+			continue
+		}
+		matches := true
+		for i, r := range prefix {
+			if digits[i] != int(r-'0') {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func digitsToInt(digits []int) int {
+	n := 0
+	for _, d := range digits {
+		n = n*10 + d
+	}
+	return n
+}
+
+// 9 digits, the last one being the usual mod 11 check digit, with weights from 9 down to 2.
+//
+// Used by most states, some of which require a prefix.
+func ieStandard(prefixes ...string) func(digits []int) bool {
+	return func(digits []int) bool {
+		return len(digits) == 9 &&
+			hasDigitPrefix(digits, prefixes...) &&
+			digits[8] == mod11CheckDigit(digits[:8], descendingWeights(9, 8))
+	}
+}
+
+// 13 digits, starting with 01, and two check digits computed just like the ones of a cnpj.
+func ieAC(digits []int) bool {
+	return len(digits) == 13 &&
+		hasDigitPrefix(digits, "01") &&
+		digits[11] == mod11CycleCheckDigit(digits[:11]) &&
+		digits[12] == mod11CycleCheckDigit(digits[:12])
+}
+
+// 13 digits, starting with 07 or 08, and two check digits computed just like the ones of a cnpj.
+func ieDF(digits []int) bool {
+	return len(digits) == 13 &&
+		hasDigitPrefix(digits, "07", "08") &&
+		digits[11] == mod11CycleCheckDigit(digits[:11]) &&
+		digits[12] == mod11CycleCheckDigit(digits[:12])
+}
+
+// 9 digits, starting with 24 followed by the company type (0, 3, 5, 7 or 8).
+func ieAL(digits []int) bool {
+	if len(digits) != 9 || !hasDigitPrefix(digits, "240", "243", "245", "247", "248") {
+		return false
+	}
+
+	checkDigit := weightedSum(digits[:8], descendingWeights(9, 8)) * 10 % 11
+	if checkDigit == 10 {
+		checkDigit = 0
+	}
+	return digits[8] == checkDigit
+}
+
+// 9 digits, starting with 03. The weighted sum is shifted according to the range of the number.
+func ieAP(digits []int) bool {
+	if len(digits) != 9 || !hasDigitPrefix(digits, "03") {
+		return false
+	}
+
+	p, d := 0, 0
+	switch number := digitsToInt(digits[:8]); {
+	case number <= 3017000:
+		p, d = 5, 0
+	case number <= 3019022:
+		p, d = 9, 1
+	}
+
+	checkDigit := 11 - (p+weightedSum(digits[:8], descendingWeights(9, 8)))%11
+	switch checkDigit {
+	case 10:
+		checkDigit = 0
+	case 11:
+		checkDigit = d
+	}
+	return digits[8] == checkDigit
+}
+
+// 8 or 9 digits, and two check digits, the last one being computed first.
+//
+// Depending on the first digit (or the second one, for 9 digits), it is either mod 10 or mod 11.
+func ieBA(digits []int) bool {
+	if len(digits) != 8 && len(digits) != 9 {
+		return false
+	}
+
+	base := digits[:len(digits)-2]
+	modulus := 10
+	switch digits[len(digits)-8] {
+	case 6, 7, 9:
+		modulus = 11
+	}
+
+	checkDigit := func(digits []int) int {
+		rest := weightedSum(digits, descendingWeights(len(digits)+1, len(digits))) % modulus
+		if (modulus == 10 && rest == 0) || (modulus == 11 && rest < 2) {
+			return 0
+		}
+		return modulus - rest
+	}
+
+	second := checkDigit(base)
+	first := checkDigit(append(append([]int{}, base...), second))
+
+	return digits[len(digits)-2] == first && digits[len(digits)-1] == second
+}
+
+// 9 digits, starting with 10, 11, 15 or 20 to 29.
+//
+// When the remainder is 1, the check digit is 1 for numbers from 10103105 to 10119997, and 0 otherwise.
+func ieGO(digits []int) bool {
+	if len(digits) != 9 || !hasDigitPrefix(digits, "10", "11", "15", "2") {
+		return false
+	}
+
+	number := digitsToInt(digits[:8])
+	if number == 11094402 {
+		return digits[8] == 0 || digits[8] == 1
+	}
+
+	checkDigit := 0
+	switch rest := weightedSum(digits[:8], descendingWeights(9, 8)) % 11; rest {
+	case 0:
+	case 1:
+		if number >= 10103105 && number <= 10119997 {
+			checkDigit = 1
+		}
+	default:
+		checkDigit = 11 - rest
+	}
+	return digits[8] == checkDigit
+}
+
+// 13 digits: 3 for the municipality, 8 for the number and two check digits.
+//
+// The first one is mod 10, over the digits with a 0 after the municipality, weighted alternately with 1 and 2,
+// summing the digits of each product. The second one is mod 11.
+func ieMG(digits []int) bool {
+	if len(digits) != 13 {
+		return false
+	}
+
+	padded := append(append(append([]int{}, digits[:3]...), 0), digits[3:11]...)
+	sum := 0
+	for i, d := range padded {
+		product := d * (1 + i%2)
+		sum += product/10 + product%10
+	}
+	first := (10 - sum%10) % 10
+
+	second := mod11CheckDigit(digits[:12], []int{3, 2, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2})
+
+	return digits[11] == first && digits[12] == second
+}
+
+// Up to 11 digits, with leading zeros when fewer.
+func ieMT(digits []int) bool {
+	if len(digits) > 11 {
+		return false
+	}
+
+	padded := append(make([]int, 11-len(digits)), digits...)
+	return padded[10] == mod11CheckDigit(padded[:10], []int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+}
+
+// 9 digits with two check digits, or the former 14 digits format (eFisco).
+func iePE(digits []int) bool {
+	switch len(digits) {
+	case 9:
+		return digits[7] == mod11CheckDigit(digits[:7], descendingWeights(8, 7)) &&
+			digits[8] == mod11CheckDigit(digits[:8], descendingWeights(9, 8))
+	case 14:
+		checkDigit := 11 - weightedSum(digits[:13], []int{5, 4, 3, 2, 1, 9, 8, 7, 6, 5, 4, 3, 2})%11
+		if checkDigit > 9 {
+			checkDigit -= 10
+		}
+		return digits[13] == checkDigit
+	}
+	return false
+}
+
+// 10 digits with two check digits.
+func iePR(digits []int) bool {
+	return len(digits) == 10 &&
+		digits[8] == mod11CheckDigit(digits[:8], []int{3, 2, 7, 6, 5, 4, 3, 2}) &&
+		digits[9] == mod11CheckDigit(digits[:9], []int{4, 3, 2, 7, 6, 5, 4, 3, 2})
+}
+
+// 8 digits.
+func ieRJ(digits []int) bool {
+	return len(digits) == 8 && digits[7] == mod11CheckDigit(digits[:7], []int{2, 7, 6, 5, 4, 3, 2})
+}
+
+// 9 or 10 digits, starting with 20.
+func ieRN(digits []int) bool {
+	if (len(digits) != 9 && len(digits) != 10) || !hasDigitPrefix(digits, "20") {
+		return false
+	}
+
+	base := digits[:len(digits)-1]
+	checkDigit := weightedSum(base, descendingWeights(len(digits), len(base))) * 10 % 11
+	if checkDigit == 10 {
+		checkDigit = 0
+	}
+	return digits[len(digits)-1] == checkDigit
+}
+
+// 14 digits. Remainders of 0 and 1 give check digits 1 and 0.
+func ieRO(digits []int) bool {
+	if len(digits) != 14 {
+		return false
+	}
+
+	checkDigit := 11 - weightedSum(digits[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})%11
+	if checkDigit >= 10 {
+		checkDigit -= 10
+	}
+	return digits[13] == checkDigit
+}
+
+// 9 digits, starting with 24. The check digit is mod 9, with weights from 1 up to 8.
+func ieRR(digits []int) bool {
+	return len(digits) == 9 &&
+		hasDigitPrefix(digits, "24") &&
+		digits[8] == weightedSum(digits[:8], []int{1, 2, 3, 4, 5, 6, 7, 8})%9
+}
+
+// 10 digits.
+func ieRS(digits []int) bool {
+	return len(digits) == 10 && digits[9] == mod11CheckDigit(digits[:9], []int{2, 9, 8, 7, 6, 5, 4, 3, 2})
+}
+
+// 12 digits, with check digits at the 9th and 12th positions.
+func ieSP(digits []int) bool {
+	return len(digits) == 12 &&
+		digits[8] == ieSPCheckDigit(digits[:8], []int{1, 3, 4, 5, 6, 7, 8, 10}) &&
+		digits[11] == ieSPCheckDigit(digits[:11], []int{3, 2, 10, 9, 8, 7, 6, 5, 4, 3, 2})
+}
+
+// The digits after the "P" of a rural producer: 12 digits, with a check digit at the 9th position.
+func ieSPRural(digits []int) bool {
+	return len(digits) == 12 && digits[8] == ieSPCheckDigit(digits[:8], []int{1, 3, 4, 5, 6, 7, 8, 10})
+}
+
+// The rightmost digit of the remainder of the weighted sum by 11.
+func ieSPCheckDigit(digits, weights []int) int {
+	return weightedSum(digits, weights) % 11 % 10
+}
+
+// 9 digits, or the former 11 digits format, in which the 3rd and 4th digits are the company type
+// (01, 02, 03 or 99) and are left out of the check digit.
+func ieTO(digits []int) bool {
+	switch len(digits) {
+	case 9:
+		return ieStandard()(digits)
+	case 11:
+		companyType := digits[2]*10 + digits[3]
+		if companyType != 1 && companyType != 2 && companyType != 3 && companyType != 99 {
+			return false
+		}
+		base := append(append([]int{}, digits[:2]...), digits[4:10]...)
+		return digits[10] == mod11CheckDigit(base, descendingWeights(9, 8))
+	}
+	return false
+}
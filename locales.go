@@ -0,0 +1,283 @@
+package safe
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The locale used when none is given, or when the given one is not registered.
+const DefaultLocale = "pt-BR"
+
+// A message in a Catalog.
+//
+// Messages may have placeholders with the name of a parameter of the rule, like "Mínimo de {min} caracteres".
+//
+// When Count is the name of a numeric parameter and One is given, One is used
+// when that parameter is 1 (or -1), and Other is used otherwise.
+type Message struct {
+	One   string `json:"one,omitempty"`
+	Other string `json:"other"`
+	Count string `json:"count,omitempty"`
+}
+
+// Lets a Message be given as a plain string in JSON files.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*m = Message{Other: text}
+		return nil
+	}
+
+	type message Message
+	return json.Unmarshal(data, (*message)(m))
+}
+
+// A set of messages for a locale, keyed by rule code (like safe.RequiredCode).
+type Catalog map[string]Message
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]Catalog{
+		"pt-BR": ptBRCatalog,
+		"en":    enCatalog,
+		"es":    esCatalog,
+	}
+)
+
+// Registers the messages of a locale, like "fr" or "pt-PT".
+//
+// In case the locale already exists, the given messages are added to it, replacing the ones with the same code.
+// This is also the way to customize the bundled messages.
+//
+// Messages missing from a locale fall back to the ones in safe.DefaultLocale.
+func RegisterLocale(locale string, catalog Catalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	merged := make(Catalog, len(catalog))
+	for code, msg := range catalogs[locale] {
+		merged[code] = msg
+	}
+	for code, msg := range catalog {
+		merged[code] = msg
+	}
+	catalogs[locale] = merged
+}
+
+// Registers the messages of a locale from a JSON file, as in safe.RegisterLocale.
+//
+// The file must be an object keyed by rule code. Each message may be a string, or an object
+// with "one", "other" and "count" keys for plural forms:
+//
+//	{
+//		"required": "Champ obligatoire",
+//		"min_chars": {"one": "Au moins {min} caractère", "other": "Au moins {min} caractères", "count": "min"}
+//	}
+func LoadLocaleFile(locale, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("safe: loading locale %s: %w", locale, err)
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return fmt.Errorf("safe: loading locale %s: %w", locale, err)
+	}
+
+	RegisterLocale(locale, catalog)
+	return nil
+}
+
+// Returns all registered locales, sorted.
+func Locales() []string {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	return sortedLocales()
+}
+
+// Returns the registered locale that best matches the given one.
+//
+// Locales are compared case insensitively, and a language alone matches a locale with a region
+// (and vice versa), so "pt" and "pt-PT" both match "pt-BR", and "en-US" matches "en".
+//
+// In case there is no match, returns an empty string.
+func MatchLocale(locale string) string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if locale == "" {
+		return ""
+	}
+	language, _, _ := strings.Cut(locale, "-")
+
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	languageMatch := ""
+	for _, registered := range sortedLocales() {
+		if strings.EqualFold(registered, locale) {
+			return registered
+		}
+		registeredLanguage, _, _ := strings.Cut(registered, "-")
+		if languageMatch == "" && strings.EqualFold(registeredLanguage, language) {
+			languageMatch = registered
+		}
+	}
+
+	return languageMatch
+}
+
+// Must be called with catalogsMu held.
+func sortedLocales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Picks a registered locale out of an HTTP Accept-Language header, like "es-AR,es;q=0.9,en;q=0.8".
+//
+// Languages are tried in order of preference (the q values). In case none of them is registered,
+// returns safe.DefaultLocale.
+//
+// Example usage:
+//
+//	locale := safe.LocaleFromAcceptLanguage(r.Header.Get("Accept-Language"))
+//	errors, ok := safe.ValidateWithLocale(fields, locale)
+func LocaleFromAcceptLanguage(header string) string {
+	type weightedLocale struct {
+		locale string
+		q      float64
+	}
+
+	var candidates []weightedLocale
+	for _, part := range strings.Split(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if key != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(val, 64)
+			if err == nil {
+				q = parsed
+			}
+		}
+
+		if q > 0 {
+			candidates = append(candidates, weightedLocale{locale: locale, q: q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	for _, candidate := range candidates {
+		if locale := MatchLocale(candidate.locale); locale != "" {
+			return locale
+		}
+	}
+
+	return DefaultLocale
+}
+
+// Returns the message for a rule code in the given locale, with its placeholders replaced by params.
+//
+// When the locale is not registered, or doesn't have a message for the code, safe.DefaultLocale is used.
+// When not even safe.DefaultLocale has it, the message for safe.InvalidCode is used.
+//
+// This is useful for custom rules with their own codes:
+//
+//	safe.RegisterLocale("en", safe.Catalog{"pineapple": {Other: "Must be a pineapple"}})
+//
+//	MessageFunc: func(rs *safe.RuleSet) string {
+//		return safe.Translate(rs.Locale, "pineapple", rs.Params)
+//	},
+func Translate(locale, code string, params map[string]any) string {
+	return interpolate(translationTemplate(locale, code, params), params)
+}
+
+// Returns the message for a rule code in the given locale, choosing the plural form according to params.
+func translationTemplate(locale, code string, params map[string]any) string {
+	msg := lookupMessage(locale, code)
+
+	if msg.One != "" && msg.Count != "" {
+		if n, ok := params[msg.Count]; ok && isOne(n) {
+			return msg.One
+		}
+	}
+
+	return msg.Other
+}
+
+func lookupMessage(locale, code string) Message {
+	catalogsMu.RLock()
+	_, registered := catalogs[locale]
+	catalogsMu.RUnlock()
+
+	if !registered {
+		locale = MatchLocale(locale)
+	}
+
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	if msg, ok := catalogs[locale][code]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[DefaultLocale][code]; ok {
+		return msg
+	}
+	return catalogs[DefaultLocale][InvalidCode]
+}
+
+func isOne(n any) bool {
+	r, ok := toRat(n)
+	return ok && new(big.Rat).Abs(r).Cmp(big.NewRat(1, 1)) == 0
+}
+
+// Replaces placeholders like {min} with the values of params.
+func interpolate(template string, params map[string]any) string {
+	if len(params) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for name, val := range params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(val))
+	}
+
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// Just like safe.Validate, but the default messages are given in the requested locale.
+//
+// Please refer to safe.MatchLocale for how locales are matched.
+//
+// Messages set with WithMessage are kept as they are.
+//
+// Example usage:
+//
+//	errors, ok := safe.ValidateWithLocale(fields, "en")
+func ValidateWithLocale(fields Fields, locale string) (ErrorMessages, bool) {
+	messages := validationErrors(fields, validation{locale: locale}).ErrorMessages()
+	return messages, len(messages) == 0
+}
+
+// Just like safe.Check, but the default messages are given in the requested locale.
+func CheckWithLocale(fields Fields, locale string) error {
+	return collectErrors(fields, validation{locale: locale})
+}
@@ -1,7 +1,5 @@
 package safe
 
-import "fmt"
-
 const (
 	MandatoryFieldMsg    = "Campo obrigatório"
 	ValueTooLongMsg      = "Valor maior do que o suportado"
@@ -10,24 +8,153 @@ const (
 	UnacceptableValueMsg = "Valor inaceitável"
 	UniqueListMsg        = "Valores na lista devem ser únicos"
 	WeakPasswordMsg      = "Senha deve ter 8+ caracteres, letras minúsculas e maiúsculas, números e símbolos"
+	InvalidValueMsg      = "Valor inválido"
 )
 
 func MinValueMsg(minValue int) string {
-	return fmt.Sprintf("Valor mínimo: %d", minValue)
+	return Translate(DefaultLocale, MinValueCode, map[string]any{"min": minValue})
 }
 
 func MinCharsMsg(minValue int) string {
-	return fmt.Sprintf("Mínimo de %d caracteres", minValue)
+	return Translate(DefaultLocale, MinCharsCode, map[string]any{"min": minValue})
 }
 
 func MaxValueMsg(maxValue int) string {
-	return fmt.Sprintf("Valor máximo: %d", maxValue)
+	return Translate(DefaultLocale, MaxValueCode, map[string]any{"max": maxValue})
 }
 
 func MaxCharsMsg(maxValue int) string {
-	return fmt.Sprintf("Máximo de %d caracteres", maxValue)
+	return Translate(DefaultLocale, MaxCharsCode, map[string]any{"max": maxValue})
 }
 
 func MaxDaysRangeMsg(maxDays int) string {
-	return fmt.Sprintf("Período não pode ser maior que %d dias.", maxDays)
+	return Translate(DefaultLocale, MaxDaysRangeCode, map[string]any{"max_days": maxDays})
+}
+
+func BetweenMsg(minValue, maxValue float64) string {
+	return Translate(DefaultLocale, BetweenCode, map[string]any{"min": minValue, "max": maxValue})
+}
+
+func BetweenExclusiveMsg(minValue, maxValue float64) string {
+	return Translate(DefaultLocale, BetweenExclusiveCode, map[string]any{"min": minValue, "max": maxValue})
+}
+
+func GreaterThanMsg(minValue float64) string {
+	return Translate(DefaultLocale, GreaterThanCode, map[string]any{"min": minValue})
+}
+
+func GreaterThanOrEqualMsg(minValue float64) string {
+	return Translate(DefaultLocale, GreaterThanOrEqualCode, map[string]any{"min": minValue})
+}
+
+func LessThanMsg(maxValue float64) string {
+	return Translate(DefaultLocale, LessThanCode, map[string]any{"max": maxValue})
+}
+
+func LessThanOrEqualMsg(maxValue float64) string {
+	return Translate(DefaultLocale, LessThanOrEqualCode, map[string]any{"max": maxValue})
+}
+
+func MultipleOfMsg(step float64) string {
+	return Translate(DefaultLocale, MultipleOfCode, map[string]any{"step": step})
+}
+
+func MaxDecimalPlacesMsg(places int) string {
+	return Translate(DefaultLocale, MaxDecimalPlacesCode, map[string]any{"places": places})
+}
+
+var ptBRCatalog = Catalog{
+	InvalidCode:           {Other: InvalidValueMsg},
+	RequiredCode:          {Other: MandatoryFieldMsg},
+	MustBeTrueCode:        {Other: MandatoryFieldMsg},
+	MustBeFalseCode:       {Other: MandatoryFieldMsg},
+	InvalidFormatCode:     {Other: InvalidFormatMsg},
+	WeakPasswordCode:      {Other: WeakPasswordMsg},
+	UniqueListCode:        {Other: UniqueListMsg},
+	MinValueCode:          {Other: "Valor mínimo: {min}"},
+	MinCharsCode:          {One: "Mínimo de {min} caractere", Other: "Mínimo de {min} caracteres", Count: "min"},
+	MaxValueCode:          {Other: "Valor máximo: {max}"},
+	MaxCharsCode:          {One: "Máximo de {max} caractere", Other: "Máximo de {max} caracteres", Count: "max"},
+	UnacceptableValueCode: {Other: UnacceptableValueMsg},
+	AfterCode:             {Other: IlogicalDatesMsg},
+	NotAfterCode:          {Other: IlogicalDatesMsg},
+	BeforeCode:            {Other: IlogicalDatesMsg},
+	NotBeforeCode:         {Other: IlogicalDatesMsg},
+	MaxDaysRangeCode:      {One: "Período não pode ser maior que {max_days} dia.", Other: "Período não pode ser maior que {max_days} dias.", Count: "max_days"},
+	CepNotInStateCode:     {Other: "CEP não pertence ao estado {uf}"},
+	CardExpiredCode:       {Other: "Cartão vencido"},
+
+	// numeric bounds, steps and scales
+	BetweenCode:            {Other: "Valor deve estar entre {min} e {max}"},
+	BetweenExclusiveCode:   {Other: "Valor deve ser maior que {min} e menor que {max}"},
+	GreaterThanCode:        {Other: "Valor deve ser maior que {min}"},
+	GreaterThanOrEqualCode: {Other: "Valor deve ser maior ou igual a {min}"},
+	LessThanCode:           {Other: "Valor deve ser menor que {max}"},
+	LessThanOrEqualCode:    {Other: "Valor deve ser menor ou igual a {max}"},
+	MultipleOfCode:         {Other: "Valor deve ser múltiplo de {step}"},
+	MaxDecimalPlacesCode:   {One: "Máximo de {places} casa decimal", Other: "Máximo de {places} casas decimais", Count: "places"},
+}
+
+var enCatalog = Catalog{
+	InvalidCode:           {Other: "Invalid value"},
+	RequiredCode:          {Other: "Required field"},
+	MustBeTrueCode:        {Other: "Required field"},
+	MustBeFalseCode:       {Other: "Required field"},
+	InvalidFormatCode:     {Other: "Invalid format"},
+	WeakPasswordCode:      {Other: "Password must have 8+ characters, lowercase and uppercase letters, numbers and symbols"},
+	UniqueListCode:        {Other: "Values in the list must be unique"},
+	MinValueCode:          {Other: "Minimum value: {min}"},
+	MinCharsCode:          {One: "At least {min} character", Other: "At least {min} characters", Count: "min"},
+	MaxValueCode:          {Other: "Maximum value: {max}"},
+	MaxCharsCode:          {One: "At most {max} character", Other: "At most {max} characters", Count: "max"},
+	UnacceptableValueCode: {Other: "Unacceptable value"},
+	AfterCode:             {Other: "Start date must be before end date"},
+	NotAfterCode:          {Other: "Start date must be before end date"},
+	BeforeCode:            {Other: "Start date must be before end date"},
+	NotBeforeCode:         {Other: "Start date must be before end date"},
+	MaxDaysRangeCode:      {One: "Period can't be longer than {max_days} day.", Other: "Period can't be longer than {max_days} days.", Count: "max_days"},
+	CepNotInStateCode:     {Other: "Postal code doesn't belong to the state {uf}"},
+	CardExpiredCode:       {Other: "Card has expired"},
+
+	// numeric bounds, steps and scales
+	BetweenCode:            {Other: "Value must be between {min} and {max}"},
+	BetweenExclusiveCode:   {Other: "Value must be greater than {min} and less than {max}"},
+	GreaterThanCode:        {Other: "Value must be greater than {min}"},
+	GreaterThanOrEqualCode: {Other: "Value must be greater than or equal to {min}"},
+	LessThanCode:           {Other: "Value must be less than {max}"},
+	LessThanOrEqualCode:    {Other: "Value must be less than or equal to {max}"},
+	MultipleOfCode:         {Other: "Value must be a multiple of {step}"},
+	MaxDecimalPlacesCode:   {One: "At most {places} decimal place", Other: "At most {places} decimal places", Count: "places"},
+}
+
+var esCatalog = Catalog{
+	InvalidCode:           {Other: "Valor inválido"},
+	RequiredCode:          {Other: "Campo obligatorio"},
+	MustBeTrueCode:        {Other: "Campo obligatorio"},
+	MustBeFalseCode:       {Other: "Campo obligatorio"},
+	InvalidFormatCode:     {Other: "Formato inválido"},
+	WeakPasswordCode:      {Other: "La contraseña debe tener 8+ caracteres, letras minúsculas y mayúsculas, números y símbolos"},
+	UniqueListCode:        {Other: "Los valores de la lista deben ser únicos"},
+	MinValueCode:          {Other: "Valor mínimo: {min}"},
+	MinCharsCode:          {One: "Mínimo de {min} carácter", Other: "Mínimo de {min} caracteres", Count: "min"},
+	MaxValueCode:          {Other: "Valor máximo: {max}"},
+	MaxCharsCode:          {One: "Máximo de {max} carácter", Other: "Máximo de {max} caracteres", Count: "max"},
+	UnacceptableValueCode: {Other: "Valor inaceptable"},
+	AfterCode:             {Other: "La fecha inicial debe ser anterior a la final"},
+	NotAfterCode:          {Other: "La fecha inicial debe ser anterior a la final"},
+	BeforeCode:            {Other: "La fecha inicial debe ser anterior a la final"},
+	NotBeforeCode:         {Other: "La fecha inicial debe ser anterior a la final"},
+	MaxDaysRangeCode:      {One: "El período no puede ser mayor que {max_days} día.", Other: "El período no puede ser mayor que {max_days} días.", Count: "max_days"},
+	CepNotInStateCode:     {Other: "El CEP no pertenece al estado {uf}"},
+	CardExpiredCode:       {Other: "Tarjeta vencida"},
+
+	// numeric bounds, steps and scales
+	BetweenCode:            {Other: "El valor debe estar entre {min} y {max}"},
+	BetweenExclusiveCode:   {Other: "El valor debe ser mayor que {min} y menor que {max}"},
+	GreaterThanCode:        {Other: "El valor debe ser mayor que {min}"},
+	GreaterThanOrEqualCode: {Other: "El valor debe ser mayor o igual a {min}"},
+	LessThanCode:           {Other: "El valor debe ser menor que {max}"},
+	LessThanOrEqualCode:    {Other: "El valor debe ser menor o igual a {max}"},
+	MultipleOfCode:         {Other: "El valor debe ser múltiplo de {step}"},
+	MaxDecimalPlacesCode:   {One: "Máximo de {places} decimal", Other: "Máximo de {places} decimales", Count: "places"},
 }
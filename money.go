@@ -0,0 +1,102 @@
+package safe
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// The minor units (decimal places) of each active currency, by its ISO 4217 code.
+var currencyMinorUnits = map[string]int{
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2, "AUD": 2, "AWG": 2, "AZN": 2,
+	"BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2, "BHD": 3, "BIF": 0, "BMD": 2, "BND": 2, "BOB": 2, "BOV": 2,
+	"BRL": 2, "BSD": 2, "BTN": 2, "BWP": 2, "BYN": 2, "BZD": 2,
+	"CAD": 2, "CDF": 2, "CHE": 2, "CHF": 2, "CHW": 2, "CLF": 4, "CLP": 0, "CNY": 2, "COP": 2, "COU": 2,
+	"CRC": 2, "CUP": 2, "CVE": 2, "CZK": 2,
+	"DJF": 0, "DKK": 2, "DOP": 2, "DZD": 2,
+	"EGP": 2, "ERN": 2, "ETB": 2, "EUR": 2,
+	"FJD": 2, "FKP": 2,
+	"GBP": 2, "GEL": 2, "GHS": 2, "GIP": 2, "GMD": 2, "GNF": 0, "GTQ": 2, "GYD": 2,
+	"HKD": 2, "HNL": 2, "HTG": 2, "HUF": 2,
+	"IDR": 2, "ILS": 2, "INR": 2, "IQD": 3, "IRR": 2, "ISK": 0,
+	"JMD": 2, "JOD": 3, "JPY": 0,
+	"KES": 2, "KGS": 2, "KHR": 2, "KMF": 0, "KPW": 2, "KRW": 0, "KWD": 3, "KYD": 2, "KZT": 2,
+	"LAK": 2, "LBP": 2, "LKR": 2, "LRD": 2, "LSL": 2, "LYD": 3,
+	"MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2, "MNT": 2, "MOP": 2, "MRU": 2, "MUR": 2, "MVR": 2,
+	"MWK": 2, "MXN": 2, "MXV": 2, "MYR": 2, "MZN": 2,
+	"NAD": 2, "NGN": 2, "NIO": 2, "NOK": 2, "NPR": 2, "NZD": 2,
+	"OMR": 3,
+	"PAB": 2, "PEN": 2, "PGK": 2, "PHP": 2, "PKR": 2, "PLN": 2, "PYG": 0,
+	"QAR": 2,
+	"RON": 2, "RSD": 2, "RUB": 2, "RWF": 0,
+	"SAR": 2, "SBD": 2, "SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2, "SHP": 2, "SLE": 2, "SOS": 2, "SRD": 2,
+	"SSP": 2, "STN": 2, "SVC": 2, "SYP": 2, "SZL": 2,
+	"THB": 2, "TJS": 2, "TMT": 2, "TND": 3, "TOP": 2, "TRY": 2, "TTD": 2, "TWD": 2, "TZS": 2,
+	"UAH": 2, "UGX": 0, "USD": 2, "USN": 2, "UYI": 0, "UYU": 2, "UYW": 4, "UZS": 2,
+	"VED": 2, "VES": 2, "VND": 0, "VUV": 0,
+	"WST": 2,
+	"XAF": 0, "XCD": 2, "XCG": 2, "XOF": 0, "XPF": 0,
+	"YER": 2,
+	"ZAR": 2, "ZMW": 2, "ZWG": 2,
+}
+
+// Formatted decimals, with optional thousands separators in groups of 3, like "1.234,56" or "1,234.56".
+var (
+	dotThousandsDecimalRegex   = regexp.MustCompile(`^[+-]?(?:\d{1,3}(?:\.\d{3})+|\d+)(?:,\d+)?$`)
+	commaThousandsDecimalRegex = regexp.MustCompile(`^[+-]?(?:\d{1,3}(?:,\d{3})+|\d+)(?:\.\d+)?$`)
+)
+
+// The format of decimals in each language: its regex, and its thousands and decimal separators.
+var decimalFormats = map[string]struct {
+	regex              *regexp.Regexp
+	thousands, decimal string
+}{
+	"pt": {dotThousandsDecimalRegex, ".", ","},
+	"es": {dotThousandsDecimalRegex, ".", ","},
+	"en": {commaThousandsDecimalRegex, ",", "."},
+}
+
+// A helper function to find out the minor units (decimal places) of a currency, by its ISO 4217 code,
+// like 2 for "BRL", 0 for "JPY" and 3 for "BHD".
+//
+// The second result is false when the currency is unknown.
+func CurrencyMinorUnits(code string) (int, bool) {
+	units, ok := currencyMinorUnits[code]
+	return units, ok
+}
+
+// A helper function to parse a decimal formatted in the given locale, like "1.234,56" in "pt-BR"
+// or "1,234.56" in "en". An empty locale means safe.DefaultLocale.
+//
+// Thousands separators are optional, but must separate groups of 3 digits. Symbols, like "R$", are not accepted.
+// Portuguese ("pt-BR", "pt-PT"), Spanish and English locales are supported.
+//
+// The second result is false when the decimal, or the locale, is not valid.
+func ParseDecimal(str, locale string) (*big.Rat, bool) {
+	normalized, ok := normalizeDecimal(str, locale)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetString(normalized)
+}
+
+// Returns a formatted decimal as in Go, like "1234.56" for "1.234,56" in "pt-BR".
+func normalizeDecimal(str, locale string) (string, bool) {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	language, _, _ := strings.Cut(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-"), "-")
+
+	format, ok := decimalFormats[language]
+	if !ok {
+		return "", false
+	}
+
+	str = strings.TrimSpace(str)
+	if !format.regex.MatchString(str) {
+		return "", false
+	}
+
+	str = strings.ReplaceAll(str, format.thousands, "")
+	return strings.Replace(str, format.decimal, ".", 1), true
+}
@@ -0,0 +1,74 @@
+package safe
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Tells if val is a number: any int or uint width, float32 and float64, named types of those kinds
+// (like `type Cents int64`), json.Number, *big.Int and *big.Rat.
+func isNumeric(val any) bool {
+	switch val.(type) {
+	case json.Number, *big.Int, *big.Rat:
+		return true
+	}
+
+	switch reflect.ValueOf(val).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// Converts a number, as in isNumeric, into a *big.Rat, so that numbers of any kind can be compared exactly.
+//
+// The second result is false when val is not a number, or it is one that can't be compared:
+// NaN, infinities, malformed json.Numbers and nil pointers.
+func toRat(val any) (*big.Rat, bool) {
+	switch val := val.(type) {
+	case json.Number:
+		if _, err := val.Float64(); err != nil {
+			return nil, false
+		}
+		return new(big.Rat).SetString(string(val))
+	case *big.Int:
+		if val == nil {
+			return nil, false
+		}
+		return new(big.Rat).SetInt(val), true
+	case *big.Rat:
+		if val == nil {
+			return nil, false
+		}
+		return new(big.Rat).Set(val), true
+	}
+
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetUint64(v.Uint()), true
+	case reflect.Float32:
+		return ratFromFloat(v.Float(), 32)
+	case reflect.Float64:
+		return ratFromFloat(v.Float(), 64)
+	}
+
+	return nil, false
+}
+
+// Converts a float into the shortest decimal that represents it, so that 0.1 is 1/10,
+// and not the binary approximation of 0.1.
+func ratFromFloat(f float64, bitSize int) (*big.Rat, bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, bitSize))
+}
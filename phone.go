@@ -0,0 +1,272 @@
+package safe
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Every area code (DDD) in use in Brazil, according to Anatel, and its state (uf).
+var ddds = map[string]string{
+	"11": "SP", "12": "SP", "13": "SP", "14": "SP", "15": "SP", "16": "SP", "17": "SP", "18": "SP", "19": "SP",
+	"21": "RJ", "22": "RJ", "24": "RJ",
+	"27": "ES", "28": "ES",
+	"31": "MG", "32": "MG", "33": "MG", "34": "MG", "35": "MG", "37": "MG", "38": "MG",
+	"41": "PR", "42": "PR", "43": "PR", "44": "PR", "45": "PR", "46": "PR",
+	"47": "SC", "48": "SC", "49": "SC",
+	"51": "RS", "53": "RS", "54": "RS", "55": "RS",
+	"61": "DF",
+	"62": "GO", "64": "GO",
+	"63": "TO",
+	"65": "MT", "66": "MT",
+	"67": "MS",
+	"68": "AC",
+	"69": "RO",
+	"71": "BA", "73": "BA", "74": "BA", "75": "BA", "77": "BA",
+	"79": "SE",
+	"81": "PE", "87": "PE",
+	"82": "AL",
+	"83": "PB",
+	"84": "RN",
+	"85": "CE", "88": "CE",
+	"86": "PI", "89": "PI",
+	"91": "PA", "93": "PA", "94": "PA",
+	"92": "AM", "97": "AM",
+	"95": "RR",
+	"96": "AP",
+	"98": "MA", "99": "MA",
+}
+
+type phoneKind int
+
+const (
+	anyPhone phoneKind = iota
+	mobilePhone
+	landlinePhone
+)
+
+// A helper function to determine if an area code (DDD), like "35", is in use in Brazil.
+func IsValidDDD(ddd string) bool {
+	_, ok := ddds[ddd]
+	return ok
+}
+
+// A helper function to convert a brazilian phone number into the E.164 format, like "+5535999445678".
+//
+// It may or may not include symbols (+-()) and whitespaces, the country code (55 or +55 or 0055)
+// and a leading 0 before the area code. The area code (DDD) is required, and must be in use.
+//
+// Mobile numbers must have 9 digits, starting with 9, and landlines must have 8 digits, starting with 2, 3, 4 or 5.
+//
+// The second result is false when the phone number is not valid, in which case the first one is empty.
+//
+// Example usage:
+//
+//	phone, ok := safe.NormalizePhone("(35) 99944-5678") // "+5535999445678", true
+func NormalizePhone(phone string) (string, bool) {
+	ddd, number, ok := parsePhone(phone, anyPhone)
+	if !ok {
+		return "", false
+	}
+	return "+55" + ddd + number, true
+}
+
+// Splits a phone number into its area code and number, validating both.
+func parsePhone(phone string, kind phoneKind) (ddd, number string, ok bool) {
+	phone = stripSymbols(strings.TrimSpace(phone), " -().")
+
+	hasPlus := strings.HasPrefix(phone, "+")
+	digits := strings.TrimPrefix(phone, "+")
+	if _, ok := parseDigits(digits); !ok {
+		return "", "", false
+	}
+
+	switch {
+	case hasPlus:
+		if !strings.HasPrefix(digits, "55") {
+			return "", "", false
+		}
+		digits = digits[2:]
+	case strings.HasPrefix(digits, "0055"):
+		digits = digits[4:]
+	case strings.HasPrefix(digits, "55") && (len(digits) == 12 || len(digits) == 13):
+		digits = digits[2:]
+	case strings.HasPrefix(digits, "0") && (len(digits) == 11 || len(digits) == 12):
+		digits = digits[1:]
+	}
+
+	if len(digits) != 10 && len(digits) != 11 {
+		return "", "", false
+	}
+
+	ddd, number = digits[:2], digits[2:]
+	if !IsValidDDD(ddd) {
+		return "", "", false
+	}
+
+	isMobile := len(number) == 9 && number[0] == '9'
+	isLandline := len(number) == 8 && number[0] >= '2' && number[0] <= '5'
+
+	switch kind {
+	case mobilePhone:
+		ok = isMobile
+	case landlinePhone:
+		ok = isLandline
+	default:
+		ok = isMobile || isLandline
+	}
+	if !ok {
+		return "", "", false
+	}
+
+	return ddd, number, true
+}
+
+// The numbering plan of a country, according to the ITU and its national regulator.
+type numberingPlan struct {
+	// ISO 3166-1 alpha-2 code, like "BR"
+	country     string
+	callingCode string
+	// dialed before national numbers within the country, like the 0 in "(0xx) 3344-5678"
+	trunkPrefix string
+	// the lengths and leading digits of valid national (significant) numbers
+	national *regexp.Regexp
+	// the leading digits of mobile numbers, or nil when they can't be told apart from landlines
+	mobile *regexp.Regexp
+}
+
+// The countries supported by safe.InternationalPhone.
+//
+// Numbers with calling code 1 are reported as "US".
+var numberingPlans = []*numberingPlan{
+	{country: "AR", callingCode: "54", trunkPrefix: "0", national: regexp.MustCompile(`^9?[1-3]\d{9}$`), mobile: regexp.MustCompile(`^9`)},
+	{country: "BR", callingCode: "55", trunkPrefix: "0", national: brazilianNationalRegex(), mobile: regexp.MustCompile(`^\d{2}9`)},
+	{country: "CL", callingCode: "56", national: regexp.MustCompile(`^[2-9]\d{8}$`), mobile: regexp.MustCompile(`^9`)},
+	{country: "CO", callingCode: "57", national: regexp.MustCompile(`^(?:3\d{9}|60\d{8})$`), mobile: regexp.MustCompile(`^3`)},
+	{country: "DE", callingCode: "49", trunkPrefix: "0", national: regexp.MustCompile(`^(?:1[5-7]\d{8,9}|[2-9]\d{5,10})$`), mobile: regexp.MustCompile(`^1[5-7]`)},
+	{country: "ES", callingCode: "34", national: regexp.MustCompile(`^[6-9]\d{8}$`), mobile: regexp.MustCompile(`^[67]`)},
+	{country: "FR", callingCode: "33", trunkPrefix: "0", national: regexp.MustCompile(`^[1-9]\d{8}$`), mobile: regexp.MustCompile(`^[67]`)},
+	{country: "GB", callingCode: "44", trunkPrefix: "0", national: regexp.MustCompile(`^(?:[1-3]\d{8,9}|[78]\d{9})$`), mobile: regexp.MustCompile(`^7[1-9]`)},
+	{country: "IT", callingCode: "39", national: regexp.MustCompile(`^(?:0\d{5,10}|3\d{8,9})$`), mobile: regexp.MustCompile(`^3`)},
+	{country: "MX", callingCode: "52", national: regexp.MustCompile(`^[1-9]\d{9}$`)},
+	{country: "PE", callingCode: "51", trunkPrefix: "0", national: regexp.MustCompile(`^(?:9\d{8}|[14-8]\d{7})$`), mobile: regexp.MustCompile(`^9`)},
+	{country: "PT", callingCode: "351", national: regexp.MustCompile(`^(?:9[1236]\d{7}|2\d{8})$`), mobile: regexp.MustCompile(`^9`)},
+	{country: "US", callingCode: "1", trunkPrefix: "1", national: regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`)},
+	{country: "UY", callingCode: "598", trunkPrefix: "0", national: regexp.MustCompile(`^(?:9[1-9]\d{6}|[24]\d{7})$`), mobile: regexp.MustCompile(`^9`)},
+}
+
+// Brazilian national numbers: an area code (DDD) in use, followed by a mobile or landline number.
+func brazilianNationalRegex() *regexp.Regexp {
+	codes := make([]string, 0, len(ddds))
+	for ddd := range ddds {
+		codes = append(codes, ddd)
+	}
+	sort.Strings(codes)
+	return regexp.MustCompile(`^(?:` + strings.Join(codes, "|") + `)(?:9\d{8}|[2-5]\d{7})$`)
+}
+
+// A phone number, as parsed by safe.ParsePhoneNumber.
+type PhoneNumber struct {
+	// ISO 3166-1 alpha-2 code of the country, like "BR"
+	Country     string
+	CallingCode string
+	// The number without the calling code nor the trunk prefix, like "35999445678"
+	NationalNumber string
+	// Tells if it is a mobile number. Always false in countries where mobile numbers can't be told apart
+	// from landlines, like the US.
+	Mobile bool
+}
+
+// Returns the number in the E.164 format, like "+5535999445678".
+func (pn PhoneNumber) E164() string {
+	return "+" + pn.CallingCode + pn.NationalNumber
+}
+
+// A helper function to parse a phone number from one of the countries supported by safe.InternationalPhone,
+// optionally restricted to the given countries (ISO 3166-1 alpha-2 codes, like "BR" and "PT").
+//
+// Numbers in the international format, starting with + or 00 and the calling code, are checked against the
+// numbering plan of the country they belong to. Numbers in the national format, as in "(35) 99944-5678", are
+// tried against each of the given countries, in order, so they are only accepted when countries are given.
+// The trunk prefix, like the 0 in "(035) 3344-5678", is only accepted in the national format.
+//
+// Symbols (+-().) and whitespaces are ignored.
+//
+// Example usage:
+//
+//	number, ok := safe.ParsePhoneNumber("+351 912 345 678")
+//	number.Country // "PT"
+//	number.E164()  // "+351912345678"
+func ParsePhoneNumber(phone string, allowedCountries ...string) (PhoneNumber, bool) {
+	phone = stripSymbols(strings.TrimSpace(phone), " -().")
+
+	international := false
+	switch {
+	case strings.HasPrefix(phone, "+"):
+		phone, international = phone[1:], true
+	case strings.HasPrefix(phone, "00"):
+		phone, international = phone[2:], true
+	}
+
+	if _, ok := parseDigits(phone); !ok || phone == "" {
+		return PhoneNumber{}, false
+	}
+
+	if international {
+		for _, plan := range numberingPlans {
+			if !strings.HasPrefix(phone, plan.callingCode) || !isAllowedCountry(plan.country, allowedCountries) {
+				continue
+			}
+			if number, ok := plan.parse(phone[len(plan.callingCode):], false); ok {
+				return number, true
+			}
+		}
+		return PhoneNumber{}, false
+	}
+
+	for _, country := range allowedCountries {
+		for _, plan := range numberingPlans {
+			if !strings.EqualFold(plan.country, country) {
+				continue
+			}
+			if number, ok := plan.parse(phone, true); ok {
+				return number, true
+			}
+		}
+	}
+
+	return PhoneNumber{}, false
+}
+
+// Checks a national number. The trunk prefix is only allowed in the national format, since it is never dialed
+// after the calling code.
+func (plan *numberingPlan) parse(number string, trunkPrefixAllowed bool) (PhoneNumber, bool) {
+	if !plan.national.MatchString(number) {
+		if !trunkPrefixAllowed || plan.trunkPrefix == "" || !strings.HasPrefix(number, plan.trunkPrefix) {
+			return PhoneNumber{}, false
+		}
+		number = number[len(plan.trunkPrefix):]
+		if !plan.national.MatchString(number) {
+			return PhoneNumber{}, false
+		}
+	}
+
+	return PhoneNumber{
+		Country:        plan.country,
+		CallingCode:    plan.callingCode,
+		NationalNumber: number,
+		Mobile:         plan.mobile != nil && plan.mobile.MatchString(number),
+	}, true
+}
+
+func isAllowedCountry(country string, allowedCountries []string) bool {
+	if len(allowedCountries) == 0 {
+		return true
+	}
+	for _, allowed := range allowedCountries {
+		if strings.EqualFold(allowed, country) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package safe
+
+import "regexp"
+
+// The type of a PIX key, as defined by BACEN.
+type PixKeyType string
+
+const (
+	PixKeyCPF   PixKeyType = "cpf"
+	PixKeyCNPJ  PixKeyType = "cnpj"
+	PixKeyEmail PixKeyType = "email"
+	PixKeyPhone PixKeyType = "phone"
+	// A random key (endereço virtual de pagamento), which is a UUID
+	PixKeyEVP PixKeyType = "evp"
+)
+
+// The maximum length of an email PIX key.
+const pixEmailMaxLength = 77
+
+var (
+	pixCpfRegex   = regexp.MustCompile(`^\d{11}$`)
+	pixCnpjRegex  = regexp.MustCompile(`^[0-9A-Z]{12}\d{2}$`)
+	pixEmailRegex = regexp.MustCompile("^[a-z0-9.!#$&'*+/=?^_`{|}~-]+@[a-z0-9-]+(?:\\.[a-z0-9-]+)*$")
+	pixPhoneRegex = regexp.MustCompile(`^\+55\d{10,11}$`)
+	pixEVPRegex   = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// A helper function to find out the type of a PIX key.
+//
+// Keys must be in the format defined by BACEN, with no symbols nor whitespaces:
+//
+//	cpf:   11 digits, with valid check digits, like "39354632009"
+//	cnpj:  14 characters, with valid check digits, like "45769852000186"
+//	email: up to 77 characters, lowercase, like "pix@example.com"
+//	phone: +55 followed by a valid area code (DDD) and number, like "+5535999445678"
+//	evp:   a lowercase UUID, like "123e4567-e89b-12d3-a456-426614174000"
+//
+// The second result is false when the key is not valid, in which case the first one is empty.
+func PixKeyTypeOf(key string) (PixKeyType, bool) {
+	var keyType PixKeyType
+	var ok bool
+
+	switch {
+	case pixCpfRegex.MatchString(key):
+		keyType, ok = PixKeyCPF, IsValidCpf(key)
+	case pixCnpjRegex.MatchString(key):
+		keyType, ok = PixKeyCNPJ, IsValidCnpj(key)
+	case pixPhoneRegex.MatchString(key):
+		_, _, ok = parsePhone(key, anyPhone)
+		keyType = PixKeyPhone
+	case pixEVPRegex.MatchString(key):
+		keyType, ok = PixKeyEVP, true
+	default:
+		keyType, ok = PixKeyEmail, len(key) <= pixEmailMaxLength && pixEmailRegex.MatchString(key)
+	}
+
+	if !ok {
+		return "", false
+	}
+	return keyType, true
+}
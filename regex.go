@@ -7,14 +7,25 @@ var WhateverRegex = regexp.MustCompile(`.*`)
 
 var EmailRegex = regexp.MustCompile(`[^@ \t\r\n]+@[^@ \t\r\n]+\.[^@ \t\r\n]+`)
 
-// with or without symbols (+-()) and whitespaces
+// with or without symbols (+-()) and whitespaces. Format only, see safe.NormalizePhone
 var PhoneRegex = regexp.MustCompile(`(?:(?:\+|00)?(55)\s?)?(?:\(?([1-9][0-9])\)?\s?)(?:((?:9\d|[2-9])\d{3})\-?(\d{4}))`)
 
 // with or without symbols (.-/)
 var CpfRegex = regexp.MustCompile(`^\d{3}\.?\d{3}\.?\d{3}\-?\d{2}$`)
 
-// with or without symbols (.-/)
-var CnpjRegex = regexp.MustCompile(`^(\d{2}\.?\d{3}\.?\d{3}\/?\d{4}\-?\d{2})$`)
+// with or without symbols (.-/), numeric or alphanumeric (letters in the first 12 positions)
+var CnpjRegex = regexp.MustCompile(`^([0-9A-Za-z]{2}\.?[0-9A-Za-z]{3}\.?[0-9A-Za-z]{3}\/?[0-9A-Za-z]{4}\-?\d{2})$`)
+
+// with or without symbols (.-), like "120.56412.54-5"
+var PisRegex = regexp.MustCompile(`^\d{3}\.?\d{5}\.?\d{2}\-?\d$`)
+
+// with or without whitespaces, like "0043 5687 0906"
+var TituloEleitorRegex = regexp.MustCompile(`^\d{4} ?\d{4} ?\d{4}$`)
+
+var CnhRegex = regexp.MustCompile(`^\d{11}$`)
+
+// with or without whitespaces, like "898 7654 3210 1233"
+var CnsRegex = regexp.MustCompile(`^\d{3} ?\d{4} ?\d{4} ?\d{4}$`)
 
 // with or without dash (-)
 var CepRegex = regexp.MustCompile(`(^\d{5})\-?(\d{3}$)`)
@@ -23,3 +34,12 @@ var CepRegex = regexp.MustCompile(`(^\d{5})\-?(\d{3}$)`)
 var AddressNumberRegex = regexp.MustCompile(`^(?:s\/n|S\/n|S\/N|s\/N)|^(\d)*$`)
 
 var UUIDRegex = regexp.MustCompile(`^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-(1|4|5|7)[a-fA-F0-9]{3}-[89abAB][a-fA-F0-9]{3}-[a-fA-F0-9]{12}$`)
+
+// old brazilian plates, with or without dash (-), like "ABC-1234"
+var PlateRegex = regexp.MustCompile(`^[A-Z]{3}-?\d{4}$`)
+
+// Mercosul plates, like "ABC1D23"
+var MercosulPlateRegex = regexp.MustCompile(`^[A-Z]{3}\d[A-Z]\d{2}$`)
+
+// 17 characters, except I, O and Q. Format only, see safe.IsValidVINCheckDigit
+var VINRegex = regexp.MustCompile(`^[A-HJ-NPR-Z0-9]{17}$`)
@@ -1,6 +1,8 @@
 package safe
 
 import (
+	"encoding/json"
+	"math/big"
 	"regexp"
 	"strings"
 	"time"
@@ -31,14 +33,30 @@ func (r Rules) String() string {
 //
 // This library exposes functions that return a *safe.RuleSet. You can also make your own!
 type RuleSet struct {
-	RuleName     string
-	FieldValue   any
+	RuleName   string
+	FieldValue any
+	// The name of the field being validated, or its path in case of nested fields, as in "address.cep".
+	FieldName string
+	// The label of the field being validated. See Field.Label.
+	FieldLabel string
+	// The locale of the current validation, as given to safe.ValidateWithLocale. Empty means safe.DefaultLocale.
+	Locale string
+	// The parameters the rule was created with, like {"max": 128} for safe.Max(128).
+	Params map[string]any
+	// Returns a stable code for the broken rule, like "required" or "max_chars", meant for API clients.
+	CodeFunc     func(*RuleSet) string
 	MessageFunc  func(*RuleSet) string
 	ValidateFunc func(*RuleSet) bool
+
+	// The rules of safe.Each, which are applied to each element of the field
+	each Rules
 }
 
 // Modifies a default message from a RuleSet, effectively letting you provide your own custom error messages.
 //
+// The message may be a template with placeholders, like {label}, {field}, {value} and the
+// parameters of the rule, such as {min} and {max}. Please refer to safe.MessageContext.
+//
 // Example usage:
 //
 //	fields := safe.Fields{
@@ -47,10 +65,34 @@ type RuleSet struct {
 //			Value: u.Username,
 //			Rules: safe.Rules{safe.Required().WithMessage("Why did you leave it blank?")},
 //		},
+//		{
+//			Name:  "email",
+//			Label: "E-mail",
+//			Value: u.Email,
+//			Rules: safe.Rules{safe.Max(128).WithMessage("O campo {label} deve ter no máximo {max} caracteres")},
+//		},
 //	}
 func (rs *RuleSet) WithMessage(msg string) *RuleSet {
 	rs.MessageFunc = func(rs *RuleSet) string {
-		return msg
+		return rs.Context().Format(msg)
+	}
+
+	return rs
+}
+
+// Modifies a default message from a RuleSet, just like WithMessage, but the message is built by a func.
+//
+// Example usage:
+//
+//	safe.Max(128).WithMessageFunc(func(ctx safe.MessageContext) string {
+//		if ctx.Locale == "en" {
+//			return fmt.Sprintf("%s is too long", ctx.Label)
+//		}
+//		return fmt.Sprintf("%s é muito longo", ctx.Label)
+//	})
+func (rs *RuleSet) WithMessageFunc(messageFunc func(ctx MessageContext) string) *RuleSet {
+	rs.MessageFunc = func(rs *RuleSet) string {
+		return messageFunc(rs.Context())
 	}
 
 	return rs
@@ -60,9 +102,27 @@ func (rs *RuleSet) String() string {
 	return rs.RuleName
 }
 
+// The MessageFunc of the built-in rules. Translates the code of the RuleSet to its locale, filling in the placeholders.
+func defaultMessage(rs *RuleSet) string {
+	return rs.Context().Format(translationTemplate(rs.Locale, rs.Code(), rs.Params))
+}
+
+// Returns the code of the RuleSet, according to its current FieldValue.
+//
+// Rules without a CodeFunc, like most custom rules, have the code "invalid".
+func (rs *RuleSet) Code() string {
+	if rs.CodeFunc == nil {
+		return InvalidCode
+	}
+	return rs.CodeFunc(rs)
+}
+
 // The field must have a value. Zero values are not allowed, except for boolean fields.
 //
-// Supported field types: bool, string, int, float64, float32, time.Time
+// Supported field types: bool, string, numbers of any kind (as in safe.Min), time.Time
+//
+// Nil pointers, slices and maps are not allowed either, so that nested fields can be required,
+// but empty slices and maps that are not nil are. Please refer to safe.HasValue.
 //
 // Example:
 //
@@ -89,9 +149,10 @@ func (rs *RuleSet) String() string {
 func Required() *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.Required",
-		MessageFunc: func(rs *RuleSet) string {
-			return MandatoryFieldMsg
+		CodeFunc: func(rs *RuleSet) string {
+			return RequiredCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			return HasValue(rs.FieldValue)
 		},
@@ -101,44 +162,916 @@ func Required() *RuleSet {
 // The field must be a bool with value of true
 func True() *RuleSet {
 	return &RuleSet{
-		RuleName: "safe.True",
-		MessageFunc: func(rs *RuleSet) string {
-			return MandatoryFieldMsg
+		RuleName: "safe.True",
+		CodeFunc: func(rs *RuleSet) string {
+			return MustBeTrueCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			boolean, ok := rs.FieldValue.(bool)
+			if !ok {
+				return false
+			}
+			return boolean == true
+		},
+	}
+}
+
+// The field must be a bool with value of false
+func False() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.False",
+		CodeFunc: func(rs *RuleSet) string {
+			return MustBeFalseCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			boolean, ok := rs.FieldValue.(bool)
+			if !ok {
+				return false
+			}
+			return boolean == false
+		},
+	}
+}
+
+// The field must be a string with a valid email format
+func Email() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.Email",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return EmailRegex.MatchString(str)
+		},
+	}
+}
+
+// The field must be a string with a valid brazilian phone number, mobile or landline.
+//
+// It may or may not include symbols (like +, - and ()), whitespaces and the country code.
+// The area code (DDD) is required, and must be in use.
+//
+// This is stricter than it used to be, when the field only had to match safe.PhoneRegex:
+// numbers without an area code, like "+55 99988-7766", are no longer valid.
+//
+// Please refer to safe.NormalizePhone.
+func Phone() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.Phone",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			_, _, ok = parsePhone(str, anyPhone)
+			return ok
+		},
+	}
+}
+
+// The field must be a string with a valid brazilian mobile phone number, with 9 digits starting with 9.
+//
+// The format is the same as in safe.Phone.
+func MobilePhone() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.MobilePhone",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			_, _, ok = parsePhone(str, mobilePhone)
+			return ok
+		},
+	}
+}
+
+// The field must be a string with a valid brazilian landline phone number, with 8 digits starting with 2, 3, 4 or 5.
+//
+// The format is the same as in safe.Phone.
+func LandlinePhone() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.LandlinePhone",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			_, _, ok = parsePhone(str, landlinePhone)
+			return ok
+		},
+	}
+}
+
+// The field must be a string with a valid phone number from one of the supported countries,
+// optionally restricted to the given ones (ISO 3166-1 alpha-2 codes, like "BR", "PT", "AR" and "US").
+//
+// Numbers in the international format (E.164, as in "+351912345678") are accepted from any of the allowed countries,
+// and numbers in the national format, as in "(35) 99944-5678", are accepted when they are valid in one of them.
+// Symbols and whitespaces are ignored.
+//
+// Please refer to safe.ParsePhoneNumber, which also tells the country of the number.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "phone",
+//			Value: customer.Phone,
+//			Rules: safe.Rules{safe.Required(), safe.InternationalPhone("BR", "PT", "AR", "US")},
+//		},
+//	}
+func InternationalPhone(allowedCountries ...string) *RuleSet {
+	var params map[string]any
+	if len(allowedCountries) > 0 {
+		params = map[string]any{"countries": allowedCountries}
+	}
+
+	return &RuleSet{
+		RuleName: "safe.InternationalPhone",
+		Params:   params,
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			_, ok = ParsePhoneNumber(str, allowedCountries...)
+			return ok
+		},
+	}
+}
+
+// The field must be a string with a valid cpf
+//
+// It may or may not include symbols. Both check digits are verified, and sequences
+// of a single repeated digit, like "111.111.111-11", are not accepted.
+//
+// Please refer to safe.IsValidCpf.
+func Cpf() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.Cpf",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return IsValidCpf(str)
+		},
+	}
+}
+
+// The field must be a string with a valid cnpj, numeric or alphanumeric
+//
+// It may or may not include symbols. Both check digits are verified, and sequences
+// of a single repeated digit, like "00.000.000/0000-00", are not accepted.
+//
+// Please refer to safe.IsValidCnpj.
+func Cnpj() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.Cnpj",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return IsValidCnpj(str)
+		},
+	}
+}
+
+// The field must be a string with a valid cpf or cnpj
+//
+// It may or may not include symbols. Cpfs and cnpjs are verified just like in safe.Cpf and safe.Cnpj.
+func CpfCnpj() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.CpfCnpj",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return IsValidCpf(str) || IsValidCnpj(str)
+		},
+	}
+}
+
+// The field must be a string with a valid PIS, PASEP or NIS
+//
+// It may or may not include symbols. The check digit is verified.
+//
+// Please refer to safe.IsValidPis.
+func Pis() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.Pis",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return IsValidPis(str)
+		},
+	}
+}
+
+// The field must be a string with a valid título de eleitor
+//
+// It may or may not include whitespaces. The state code and both check digits are verified.
+//
+// Please refer to safe.TituloEleitorUF.
+func TituloEleitor() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.TituloEleitor",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return IsValidTituloEleitor(str)
+		},
+	}
+}
+
+// The field must be a string with a valid CNH (carteira nacional de habilitação)
+//
+// Both check digits are verified.
+//
+// Please refer to safe.IsValidCnh.
+func Cnh() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.Cnh",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return IsValidCnh(str)
+		},
+	}
+}
+
+// The field must be a string with a valid CNS (cartão nacional de saúde)
+//
+// It may or may not include whitespaces. The check digits are verified.
+//
+// Please refer to safe.IsValidCns.
+func Cns() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.Cns",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return IsValidCns(str)
+		},
+	}
+}
+
+// The field must be a string with a valid inscrição estadual for the given state (uf), like "MG"
+//
+// It may or may not include symbols. When the uf is unknown, the field is never valid.
+// To accept "ISENTO" as well, please refer to safe.InscricaoEstadualOrIsento.
+//
+// Please refer to safe.IsValidInscricaoEstadual.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "ie",
+//			Value: company.IE,
+//			Rules: safe.Rules{safe.Required(), safe.InscricaoEstadual(company.UF)},
+//		},
+//	}
+func InscricaoEstadual(uf string) *RuleSet {
+	return InscricaoEstadualFrom(&uf)
+}
+
+// Just like safe.InscricaoEstadual, but the uf is read from the given pointer when the rule is validated,
+// instead of when it is built.
+//
+// This is useful when the rules are built before the uf is known, as in a struct
+// whose fields are filled in later by a decoder:
+//
+//	fields.SetRules("ie", safe.Rules{safe.InscricaoEstadualFrom(&company.UF)})
+func InscricaoEstadualFrom(uf *string) *RuleSet {
+	return inscricaoEstadual("safe.InscricaoEstadual", uf, false)
+}
+
+// Just like safe.InscricaoEstadual, but "ISENTO", case insensitive, is accepted as well,
+// for taxpayers exempt from the inscrição estadual.
+func InscricaoEstadualOrIsento(uf string) *RuleSet {
+	return InscricaoEstadualOrIsentoFrom(&uf)
+}
+
+// Just like safe.InscricaoEstadualFrom, but "ISENTO", case insensitive, is accepted as well.
+func InscricaoEstadualOrIsentoFrom(uf *string) *RuleSet {
+	return inscricaoEstadual("safe.InscricaoEstadualOrIsento", uf, true)
+}
+
+func inscricaoEstadual(ruleName string, uf *string, allowIsento bool) *RuleSet {
+	return &RuleSet{
+		RuleName: ruleName,
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			if allowIsento && IsIsentoIE(str) {
+				return true
+			}
+
+			if uf == nil {
+				return false
+			}
+
+			rs.Params = map[string]any{"uf": *uf}
+			return IsValidInscricaoEstadual(str, *uf)
+		},
+	}
+}
+
+// The field must be a string with a valid PIX key, of any type
+//
+// Please refer to safe.PixKeyTypeOf for the format of each type.
+func PixKey() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.PixKey",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			_, ok = PixKeyTypeOf(str)
+			return ok
+		},
+	}
+}
+
+// The field must be a string with a valid PIX key of the given type, like safe.PixKeyEmail
+//
+// Please refer to safe.PixKeyTypeOf for the format of each type.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "pix_key",
+//			Value: payout.PixKey,
+//			Rules: safe.Rules{safe.Required(), safe.PixKeyOfType(safe.PixKeyType(payout.PixKeyType))},
+//		},
+//	}
+func PixKeyOfType(keyType PixKeyType) *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.PixKeyOfType",
+		Params:   map[string]any{"type": keyType},
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			matched, ok := PixKeyTypeOf(str)
+			return ok && matched == keyType
+		},
+	}
+}
+
+// The field must be a string with a valid boleto barcode, with 44 digits
+//
+// Both bank boletos and convênio (arrecadação) slips are accepted, and the general check digit is verified.
+// Whitespaces are ignored.
+//
+// Please refer to safe.ParseBoleto.
+func BoletoBarcode() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.BoletoBarcode",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			boleto, ok := ParseBoleto(str)
+			return ok && boleto.Barcode == stripSymbols(str, " ")
+		},
+	}
+}
+
+// The field must be a string with a valid boleto linha digitável, with 47 digits for bank boletos
+// or 48 digits for convênio (arrecadação) slips
+//
+// It may or may not include symbols (.-) and whitespaces, and the check digit of each field is verified.
+//
+// Please refer to safe.ParseBoleto.
+func LinhaDigitavel() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.LinhaDigitavel",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			digits := stripSymbols(str, " .-")
+			if len(digits) != 47 && len(digits) != 48 {
+				return false
+			}
+
+			_, ok = ParseBoleto(digits)
+			return ok
+		},
+	}
+}
+
+// The field must be a string with a valid chave de acesso of a fiscal document, like NF-e, NFC-e and CT-e,
+// optionally restricted to the given models, like "55" (NF-e) and "65" (NFC-e)
+//
+// It may or may not include whitespaces.
+//
+// Please refer to safe.ParseChaveAcesso.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "chave_acesso",
+//			Value: nfe.ChaveAcesso,
+//			Rules: safe.Rules{safe.Required(), safe.ChaveAcesso("55", "65")},
+//		},
+//	}
+func ChaveAcesso(models ...string) *RuleSet {
+	var params map[string]any
+	if len(models) > 0 {
+		params = map[string]any{"models": models}
+	}
+
+	return &RuleSet{
+		RuleName: "safe.ChaveAcesso",
+		Params:   params,
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			chave, ok := ParseChaveAcesso(str)
+			if !ok {
+				return false
+			}
+
+			if len(models) == 0 {
+				return true
+			}
+			for _, model := range models {
+				if chave.Model == model {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// The field must be a string with a valid brazilian plate, either old (like "ABC-1234") or Mercosul (like "ABC1D23")
+//
+// Letters may be lowercase.
+func Plate() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.Plate",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			str = strings.ToUpper(str)
+			return PlateRegex.MatchString(str) || MercosulPlateRegex.MatchString(str)
+		},
+	}
+}
+
+// The field must be a string with a valid Mercosul plate, like "ABC1D23"
+//
+// Letters may be lowercase. Please refer to safe.PlateToMercosul for converting old plates.
+func MercosulPlate() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.MercosulPlate",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return MercosulPlateRegex.MatchString(strings.ToUpper(str))
+		},
+	}
+}
+
+// The field must be a string with a valid RENAVAM, with 11 digits
+//
+// Please refer to safe.IsValidRenavam.
+func Renavam() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.Renavam",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return IsValidRenavam(str)
+		},
+	}
+}
+
+// The field must be a string with a valid VIN (vehicle identification number, or chassi), with 17 characters
+//
+// The check digit is not verified, please refer to safe.VINWithCheckDigit and safe.IsValidVIN.
+func VIN() *RuleSet {
+	return vinRule("safe.VIN", IsValidVIN)
+}
+
+// The field must be a string with a valid VIN, as in safe.VIN, whose check digit (9th position) is valid,
+// as required in North America
+//
+// Please refer to safe.IsValidVINCheckDigit.
+func VINWithCheckDigit() *RuleSet {
+	return vinRule("safe.VINWithCheckDigit", IsValidVINCheckDigit)
+}
+
+func vinRule(ruleName string, isValid func(vin string) bool) *RuleSet {
+	return &RuleSet{
+		RuleName: ruleName,
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return isValid(str)
+		},
+	}
+}
+
+// The field must be a string with the COMPE code (3 digits, like "001") or the ISPB code (8 digits, like "00000000")
+// of a known bank
+//
+// Please refer to safe.BankByCode.
+func BankCode() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.BankCode",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			_, ok = BankByCode(str)
+			return ok
+		},
+	}
+}
+
+// The field must be a string with a valid agency of the given bank (COMPE or ISPB code)
+//
+// Please refer to safe.IsValidBankAgency.
+func BankAgency(bankCode string) *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.BankAgency",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			return IsValidBankAgency(bankCode, str)
+		},
+	}
+}
+
+// The field must be a string with a valid account of the given bank (COMPE or ISPB code) and agency
+//
+// Some banks, like Itaú, Santander and Caixa, compute the check digit of accounts out of the agency as well.
+// Please refer to safe.IsValidBankAccount.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "account",
+//			Value: payout.Account,
+//			Rules: safe.Rules{safe.Required(), safe.BankAccount(payout.BankCode, payout.Agency)},
+//		},
+//	}
+func BankAccount(bankCode, agency string) *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.BankAccount",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
-			boolean, ok := rs.FieldValue.(bool)
+			str, ok := rs.FieldValue.(string)
 			if !ok {
 				return false
 			}
-			return boolean == true
+
+			if str == "" {
+				return true
+			}
+
+			return IsValidBankAccount(bankCode, agency, str)
 		},
 	}
 }
 
-// The field must be a bool with value of false
-func False() *RuleSet {
+// The field must be a string with a valid card number, with or without whitespaces and dashes (-)
+//
+// The check digit (Luhn) is verified. When brands are given, the number must be of one of them.
+// Please refer to safe.IsValidCardNumber and safe.CardBrandOf.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "card_number",
+//			Value: payment.CardNumber,
+//			Rules: safe.Rules{safe.Required(), safe.CardNumber(safe.CardVisa, safe.CardMastercard, safe.CardElo)},
+//		},
+//	}
+func CardNumber(brands ...CardBrand) *RuleSet {
+	var params map[string]any
+	if len(brands) > 0 {
+		params = map[string]any{"brands": brands}
+	}
+
 	return &RuleSet{
-		RuleName: "safe.False",
-		MessageFunc: func(rs *RuleSet) string {
-			return MandatoryFieldMsg
+		RuleName: "safe.CardNumber",
+		Params:   params,
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
-			boolean, ok := rs.FieldValue.(bool)
+			str, ok := rs.FieldValue.(string)
 			if !ok {
 				return false
 			}
-			return boolean == false
+
+			if str == "" {
+				return true
+			}
+
+			if len(brands) == 0 {
+				return IsValidCardNumber(str)
+			}
+
+			brand, ok := CardBrandOf(str)
+			if !ok {
+				return false
+			}
+			for _, b := range brands {
+				if brand == b {
+					return true
+				}
+			}
+			return false
 		},
 	}
 }
 
-// The field must be a string with a valid email format
-func Email() *RuleSet {
+// The field must be a string with the expiry date of a card, like "12/29" or "12/2029", that is not in the past
+//
+// Cards are valid up to the last day of their expiry month. Please refer to safe.ParseCardExpiry.
+func CardExpiry() *RuleSet {
+	return CardExpiryAt(time.Now)
+}
+
+// Just like safe.CardExpiry, but the current date is read from the given clock, instead of time.Now,
+// when the rule is validated. This is useful to make tests deterministic:
+//
+//	safe.CardExpiryAt(func() time.Time { return time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC) })
+func CardExpiryAt(now func() time.Time) *RuleSet {
 	return &RuleSet{
-		RuleName: "safe.Email",
-		MessageFunc: func(rs *RuleSet) string {
-			return InvalidFormatMsg
+		RuleName: "safe.CardExpiry",
+		CodeFunc: func(rs *RuleSet) string {
+			str, _ := rs.FieldValue.(string)
+			if _, _, ok := ParseCardExpiry(str); !ok {
+				return InvalidFormatCode
+			}
+			return CardExpiredCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			str, ok := rs.FieldValue.(string)
 			if !ok {
@@ -149,21 +1082,22 @@ func Email() *RuleSet {
 				return true
 			}
 
-			return EmailRegex.MatchString(str)
+			year, month, ok := ParseCardExpiry(str)
+			return ok && !isCardExpired(year, month, now())
 		},
 	}
 }
 
-// The field must be a string with a valid phone format.
+// The field must be a string with the security code (CVV) of a card of the given brand
 //
-// It may or may not include symbols (like +, - and ())
-// or whitespaces
-func Phone() *RuleSet {
+// American Express cards have 4 digits, and the others have 3. When the brand is unknown (""), both are accepted.
+func CVV(brand CardBrand) *RuleSet {
 	return &RuleSet{
-		RuleName: "safe.Phone",
-		MessageFunc: func(rs *RuleSet) string {
-			return InvalidFormatMsg
+		RuleName: "safe.CVV",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			str, ok := rs.FieldValue.(string)
 			if !ok {
@@ -174,20 +1108,36 @@ func Phone() *RuleSet {
 				return true
 			}
 
-			return PhoneRegex.MatchString(str)
+			if _, ok := parseDigits(str); !ok {
+				return false
+			}
+
+			if brand == "" {
+				return len(str) == 3 || len(str) == 4
+			}
+			return len(str) == cvvLength(brand)
 		},
 	}
 }
 
-// The field must be a string with a valid cpf format
+// The field must be a string with a valid IBAN, in its electronic or paper format,
+// like "DE89370400440532013000" or "DE89 3704 0044 0532 0130 00"
 //
-// It may or may not include symbols
-func Cpf() *RuleSet {
+// When countries are given, as in "DE" and "PT", the IBAN must be of one of them.
+// Please refer to safe.IsValidIBAN.
+func IBAN(allowedCountries ...string) *RuleSet {
+	var params map[string]any
+	if len(allowedCountries) > 0 {
+		params = map[string]any{"countries": allowedCountries}
+	}
+
 	return &RuleSet{
-		RuleName: "safe.Cpf",
-		MessageFunc: func(rs *RuleSet) string {
-			return InvalidFormatMsg
+		RuleName: "safe.IBAN",
+		Params:   params,
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			str, ok := rs.FieldValue.(string)
 			if !ok {
@@ -198,20 +1148,34 @@ func Cpf() *RuleSet {
 				return true
 			}
 
-			return CpfRegex.MatchString(str)
+			if !IsValidIBAN(str) {
+				return false
+			}
+
+			if len(allowedCountries) == 0 {
+				return true
+			}
+			country := NormalizeIBAN(str)[:2]
+			for _, allowed := range allowedCountries {
+				if strings.EqualFold(country, allowed) {
+					return true
+				}
+			}
+			return false
 		},
 	}
 }
 
-// The field must be a string with a valid cnpj format
+// The field must be a string with a valid SWIFT/BIC code, with 8 or 11 characters, like "DEUTDEFF" or "DEUTDEFF500"
 //
-// It may or may not include symbols
-func Cnpj() *RuleSet {
+// Please refer to safe.IsValidBIC.
+func BIC() *RuleSet {
 	return &RuleSet{
-		RuleName: "safe.Cnpj",
-		MessageFunc: func(rs *RuleSet) string {
-			return InvalidFormatMsg
+		RuleName: "safe.BIC",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			str, ok := rs.FieldValue.(string)
 			if !ok {
@@ -222,20 +1186,19 @@ func Cnpj() *RuleSet {
 				return true
 			}
 
-			return CnpjRegex.MatchString(str)
+			return IsValidBIC(str)
 		},
 	}
 }
 
-// The field must be a string with a valid cpf or cnpj format
-//
-// It may or may not include symbols
-func CpfCnpj() *RuleSet {
+// The field must be a string with a valid cep format
+func CEP() *RuleSet {
 	return &RuleSet{
-		RuleName: "safe.CpfCnpj",
-		MessageFunc: func(rs *RuleSet) string {
-			return InvalidFormatMsg
+		RuleName: "safe.CEP",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			str, ok := rs.FieldValue.(string)
 			if !ok {
@@ -246,18 +1209,39 @@ func CpfCnpj() *RuleSet {
 				return true
 			}
 
-			return CpfRegex.MatchString(str) || CnpjRegex.MatchString(str)
+			return CepRegex.MatchString(str)
 		},
 	}
 }
 
-// The field must be a string with a valid cep format
-func CEP() *RuleSet {
+// The field must be a string with a valid cep, belonging to the given state (uf), like "SP"
+//
+// This catches the common mistake of a cep from another state in an address form.
+// When the uf is unknown, the field is never valid.
+//
+// Please refer to safe.UFFromCEP.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "cep",
+//			Value: address.Cep,
+//			Rules: safe.Rules{safe.Required(), safe.CEPInState(address.UF)},
+//		},
+//	}
+func CEPInState(uf string) *RuleSet {
 	return &RuleSet{
-		RuleName: "safe.CEP",
-		MessageFunc: func(rs *RuleSet) string {
-			return InvalidFormatMsg
+		RuleName: "safe.CEPInState",
+		Params:   map[string]any{"uf": uf},
+		CodeFunc: func(rs *RuleSet) string {
+			str, _ := rs.FieldValue.(string)
+			if !CepRegex.MatchString(str) {
+				return InvalidFormatCode
+			}
+			return CepNotInStateCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			str, ok := rs.FieldValue.(string)
 			if !ok {
@@ -268,7 +1252,8 @@ func CEP() *RuleSet {
 				return true
 			}
 
-			return CepRegex.MatchString(str)
+			cepUF, ok := UFFromCEP(str)
+			return ok && strings.EqualFold(cepUF, strings.TrimSpace(uf))
 		},
 	}
 }
@@ -279,9 +1264,10 @@ func CEP() *RuleSet {
 func StrongPassword() *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.StrongPassword",
-		MessageFunc: func(rs *RuleSet) string {
-			return WeakPasswordMsg
+		CodeFunc: func(rs *RuleSet) string {
+			return WeakPasswordCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			pwd, ok := rs.FieldValue.(string)
 			if !ok {
@@ -306,9 +1292,10 @@ func StrongPassword() *RuleSet {
 func UUIDstr() *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.UUIDstr",
-		MessageFunc: func(rs *RuleSet) string {
-			return InvalidFormatMsg
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			uuid, ok := rs.FieldValue.(string)
 			if !ok {
@@ -348,9 +1335,10 @@ func UUIDstr() *RuleSet {
 func UniqueList[T comparable]() *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.UniqueList",
-		MessageFunc: func(rs *RuleSet) string {
-			return UniqueListMsg
+		CodeFunc: func(rs *RuleSet) string {
+			return UniqueListCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			vals, ok := rs.FieldValue.([]T)
 			if !ok {
@@ -370,9 +1358,11 @@ func UniqueList[T comparable]() *RuleSet {
 func Match(regexes ...*regexp.Regexp) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.Match",
-		MessageFunc: func(rs *RuleSet) string {
-			return InvalidFormatMsg
+		Params:   map[string]any{"regexes": regexes},
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			str, ok := rs.FieldValue.(string)
 			if !ok {
@@ -396,13 +1386,58 @@ func Match(regexes ...*regexp.Regexp) *RuleSet {
 
 }
 
+// The field must be a slice or an array, and each of its elements must satisfy all the given rules.
+//
+// Broken rules are reported by the index of the element, as in "emails[3]", and, unless all errors are requested,
+// only the first broken rule of each element is reported. Nil slices and nil values have no elements to validate.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "emails",
+//			Value: user.Emails, // []string
+//			Rules: safe.Rules{safe.Required(), safe.Each(safe.Required(), safe.Email(), safe.Max(128))},
+//		},
+//		{
+//			Name:  "scores",
+//			Value: user.Scores, // []int
+//			Rules: safe.Rules{safe.Each(safe.Min(0), safe.Max(10))},
+//		},
+//	}
+//
+// Rules may be nested, as in safe.Each(safe.Each(safe.Cpf())), for slices of slices.
+func Each(rules ...*RuleSet) *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.Each",
+		each:     rules,
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			if rs.FieldValue == nil {
+				return true
+			}
+
+			if !isList(rs.FieldValue) {
+				return false
+			}
+
+			return validateEach(rs, validation{}, func(string, *RuleSet) {})
+		},
+	}
+}
+
 // The field must be a slice of string, in which all strings match all the given regexes.
 func MatchList(regexes ...*regexp.Regexp) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.MatchList",
-		MessageFunc: func(rs *RuleSet) string {
-			return InvalidFormatMsg
+		Params:   map[string]any{"regexes": regexes},
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			if rs.FieldValue == nil {
 				return true
@@ -432,30 +1467,31 @@ func MatchList(regexes ...*regexp.Regexp) *RuleSet {
 
 }
 
-// The field must be an int, float64, float32 or string.
+// The field must be a number or a string.
 //
-// In case it is a numeric value, it must not be less then minValue.
+// In case it is a numeric value, it must not be less then minValue. Every int and uint width, float32, float64,
+// named numeric types (like `type Cents int64`), json.Number, *big.Int and *big.Rat are supported.
 //
 // As for strings, they must not have less then minValue number of characters.
 func Min(minValue int) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.Min",
-		MessageFunc: func(rs *RuleSet) string {
-			switch rs.FieldValue.(type) {
-			case int, float32, float64:
-				return MinValueMsg(minValue)
+		Params:   map[string]any{"min": minValue},
+		CodeFunc: func(rs *RuleSet) string {
+			switch {
+			case isNumeric(rs.FieldValue):
+				return MinValueCode
 			default:
-				return MinCharsMsg(minValue)
+				return MinCharsCode
 			}
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
+			if n, ok := toRat(rs.FieldValue); ok {
+				return n.Cmp(big.NewRat(int64(minValue), 1)) >= 0
+			}
+
 			switch val := rs.FieldValue.(type) {
-			case int:
-				return val >= minValue
-			case float64:
-				return val >= float64(minValue)
-			case float32:
-				return val >= float32(minValue)
 			case string:
 				if val == "" {
 					return true
@@ -469,30 +1505,30 @@ func Min(minValue int) *RuleSet {
 
 }
 
-// The field must be an int, float64, float32 or string.
+// The field must be a number or a string.
 //
-// In case it is a numeric value, it must not greater then maxValue.
+// In case it is a numeric value, it must not greater then maxValue. Numbers of any kind are supported, as in safe.Min.
 //
 // As for strings, they must not have more then maxValue number of characters.
 func Max(maxValue int) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.Max",
-		MessageFunc: func(rs *RuleSet) string {
-			switch rs.FieldValue.(type) {
-			case int, float32, float64:
-				return MaxValueMsg(maxValue)
+		Params:   map[string]any{"max": maxValue},
+		CodeFunc: func(rs *RuleSet) string {
+			switch {
+			case isNumeric(rs.FieldValue):
+				return MaxValueCode
 			default:
-				return MaxCharsMsg(maxValue)
+				return MaxCharsCode
 			}
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
+			if n, ok := toRat(rs.FieldValue); ok {
+				return n.Cmp(big.NewRat(int64(maxValue), 1)) <= 0
+			}
+
 			switch val := rs.FieldValue.(type) {
-			case int:
-				return val <= maxValue
-			case float64:
-				return val <= float64(maxValue)
-			case float32:
-				return val <= float32(maxValue)
 			case string:
 				if val == "" {
 					return true
@@ -505,6 +1541,253 @@ func Max(maxValue int) *RuleSet {
 	}
 }
 
+// A rule for numbers of any kind, as in safe.Min, which are compared as exact fractions.
+//
+// Values that are not numbers are never valid.
+func numericRule(ruleName, code string, params map[string]any, validate func(n *big.Rat) bool) *RuleSet {
+	return &RuleSet{
+		RuleName: ruleName,
+		Params:   params,
+		CodeFunc: func(rs *RuleSet) string {
+			return code
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			n, ok := toRat(rs.FieldValue)
+			return ok && validate(n)
+		},
+	}
+}
+
+// The field must be a number between minValue and maxValue, inclusive.
+//
+// Numbers of any kind are supported, as in safe.Min. Floats are compared by their shortest decimal representation,
+// so 0.1 is exactly one tenth.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "discount",
+//			Value: product.Discount,
+//			Rules: safe.Rules{safe.Between(0.5, 99.99)},
+//		},
+//	}
+func Between(minValue, maxValue float64) *RuleSet {
+	lower, _ := ratFromFloat(minValue, 64)
+	upper, _ := ratFromFloat(maxValue, 64)
+	params := map[string]any{"min": minValue, "max": maxValue}
+
+	return numericRule("safe.Between", BetweenCode, params, func(n *big.Rat) bool {
+		return lower != nil && upper != nil && n.Cmp(lower) >= 0 && n.Cmp(upper) <= 0
+	})
+}
+
+// The field must be a number greater than minValue and less than maxValue.
+//
+// Just like safe.Between, but the bounds themselves are not accepted.
+func BetweenExclusive(minValue, maxValue float64) *RuleSet {
+	lower, _ := ratFromFloat(minValue, 64)
+	upper, _ := ratFromFloat(maxValue, 64)
+	params := map[string]any{"min": minValue, "max": maxValue}
+
+	return numericRule("safe.BetweenExclusive", BetweenExclusiveCode, params, func(n *big.Rat) bool {
+		return lower != nil && upper != nil && n.Cmp(lower) > 0 && n.Cmp(upper) < 0
+	})
+}
+
+// The field must be a number greater than minValue, as in "price > 0.00".
+//
+// Numbers of any kind are supported, as in safe.Between.
+func GreaterThan(minValue float64) *RuleSet {
+	lower, _ := ratFromFloat(minValue, 64)
+
+	return numericRule("safe.GreaterThan", GreaterThanCode, map[string]any{"min": minValue}, func(n *big.Rat) bool {
+		return lower != nil && n.Cmp(lower) > 0
+	})
+}
+
+// The field must be a number greater than or equal to minValue.
+//
+// Unlike safe.Min, the bound may be a float, and strings are not accepted.
+func GreaterThanOrEqual(minValue float64) *RuleSet {
+	lower, _ := ratFromFloat(minValue, 64)
+
+	return numericRule("safe.GreaterThanOrEqual", GreaterThanOrEqualCode, map[string]any{"min": minValue}, func(n *big.Rat) bool {
+		return lower != nil && n.Cmp(lower) >= 0
+	})
+}
+
+// The field must be a number less than maxValue.
+//
+// Numbers of any kind are supported, as in safe.Between.
+func LessThan(maxValue float64) *RuleSet {
+	upper, _ := ratFromFloat(maxValue, 64)
+
+	return numericRule("safe.LessThan", LessThanCode, map[string]any{"max": maxValue}, func(n *big.Rat) bool {
+		return upper != nil && n.Cmp(upper) < 0
+	})
+}
+
+// The field must be a number less than or equal to maxValue.
+//
+// Unlike safe.Max, the bound may be a float, and strings are not accepted.
+func LessThanOrEqual(maxValue float64) *RuleSet {
+	upper, _ := ratFromFloat(maxValue, 64)
+
+	return numericRule("safe.LessThanOrEqual", LessThanOrEqualCode, map[string]any{"max": maxValue}, func(n *big.Rat) bool {
+		return upper != nil && n.Cmp(upper) <= 0
+	})
+}
+
+// The field must be a number that is a multiple of step, like 0.05 for prices rounded to 5 cents.
+//
+// Numbers of any kind are supported, as in safe.Between. A step of zero is never satisfied.
+func MultipleOf(step float64) *RuleSet {
+	divisor, _ := ratFromFloat(step, 64)
+
+	return numericRule("safe.MultipleOf", MultipleOfCode, map[string]any{"step": step}, func(n *big.Rat) bool {
+		if divisor == nil || divisor.Sign() == 0 {
+			return false
+		}
+		return new(big.Rat).Quo(n, divisor).IsInt()
+	})
+}
+
+// The field must be a number with up to the given number of decimal places, as in 2 for money
+// and 4 for percentages like "12.3456".
+//
+// Trailing zeros are not counted, so json.Number("1.50") has a single decimal place.
+// Numbers of any kind are supported, as in safe.Between.
+func MaxDecimalPlaces(places int) *RuleSet {
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil))
+
+	return numericRule("safe.MaxDecimalPlaces", MaxDecimalPlacesCode, map[string]any{"places": places}, func(n *big.Rat) bool {
+		return new(big.Rat).Mul(n, scale).IsInt()
+	})
+}
+
+// The field must be a string with a decimal formatted in the given locale, like "1.234,56" in "pt-BR",
+// which must satisfy the given numeric rules.
+//
+// When the locale is empty, the locale of the validation is used, as given to safe.ValidateWithLocale.
+// Please refer to safe.ParseDecimal.
+//
+// The rules receive the parsed value as a json.Number, like "1234.56", so any rule for numbers may be used,
+// such as safe.Between, safe.MaxDecimalPlaces and safe.CurrencyAmount. The code, the parameters and the message
+// of the first broken rule are reported.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "price",
+//			Value: form.Price, // "1.234,56"
+//			Rules: safe.Rules{safe.Required(), safe.DecimalString("pt-BR", safe.GreaterThan(0), safe.CurrencyAmount("BRL"))},
+//		},
+//	}
+func DecimalString(locale string, rules ...*RuleSet) *RuleSet {
+	var broken *RuleSet
+
+	return &RuleSet{
+		RuleName: "safe.DecimalString",
+		CodeFunc: func(rs *RuleSet) string {
+			if broken != nil {
+				return broken.Code()
+			}
+			return InvalidFormatCode
+		},
+		MessageFunc: func(rs *RuleSet) string {
+			if broken != nil {
+				return broken.MessageFunc(broken)
+			}
+			return defaultMessage(rs)
+		},
+		ValidateFunc: func(rs *RuleSet) bool {
+			broken = nil
+			rs.Params = nil
+
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			decimalLocale := locale
+			if decimalLocale == "" {
+				decimalLocale = rs.Locale
+			}
+
+			normalized, ok := normalizeDecimal(str, decimalLocale)
+			if !ok {
+				return false
+			}
+
+			for _, rule := range rules {
+				rule.FieldValue = json.Number(normalized)
+				rule.FieldName = rs.FieldName
+				rule.FieldLabel = rs.FieldLabel
+				rule.Locale = rs.Locale
+				if !rule.ValidateFunc(rule) {
+					broken = rule
+					rs.Params = rule.Params
+					return false
+				}
+			}
+
+			return true
+		},
+	}
+}
+
+// The field must be a number with no more decimal places than the minor units of the given currency,
+// by its ISO 4217 code, like 2 for "BRL", 0 for "JPY" and 3 for "BHD".
+//
+// Numbers of any kind are supported, as in safe.Between. Along with safe.DecimalString, it validates
+// formatted amounts, like "1.234,56". Unknown currencies are never satisfied.
+func CurrencyAmount(currency string) *RuleSet {
+	places, ok := CurrencyMinorUnits(currency)
+	if !ok {
+		return numericRule("safe.CurrencyAmount", InvalidCode, map[string]any{"currency": currency}, func(n *big.Rat) bool {
+			return false
+		})
+	}
+
+	rs := MaxDecimalPlaces(places)
+	rs.RuleName = "safe.CurrencyAmount"
+	rs.Params["currency"] = currency
+	return rs
+}
+
+// The field must be a string with the ISO 4217 code of an active currency, in uppercase, like "BRL" or "USD"
+//
+// Please refer to safe.CurrencyMinorUnits.
+func CurrencyCode() *RuleSet {
+	return &RuleSet{
+		RuleName: "safe.CurrencyCode",
+		CodeFunc: func(rs *RuleSet) string {
+			return InvalidFormatCode
+		},
+		MessageFunc: defaultMessage,
+		ValidateFunc: func(rs *RuleSet) bool {
+			str, ok := rs.FieldValue.(string)
+			if !ok {
+				return false
+			}
+
+			if str == "" {
+				return true
+			}
+
+			_, ok = CurrencyMinorUnits(str)
+			return ok
+		},
+	}
+}
+
 // The field value must implement the comparable interface.
 //
 // The value of the field should be equal to at least one of the provided values.
@@ -529,9 +1812,11 @@ func Max(maxValue int) *RuleSet {
 func OneOf[T comparable](vals []T) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.OneOf",
-		MessageFunc: func(rs *RuleSet) string {
-			return UnacceptableValueMsg
+		Params:   map[string]any{"values": vals},
+		CodeFunc: func(rs *RuleSet) string {
+			return UnacceptableValueCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			for _, val := range vals {
 				if val == rs.FieldValue {
@@ -548,9 +1833,11 @@ func OneOf[T comparable](vals []T) *RuleSet {
 func NotOneOf[T comparable](vals []T) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.NotOneOf",
-		MessageFunc: func(rs *RuleSet) string {
-			return UnacceptableValueMsg
+		Params:   map[string]any{"values": vals},
+		CodeFunc: func(rs *RuleSet) string {
+			return UnacceptableValueCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			for _, val := range vals {
 				if val == rs.FieldValue {
@@ -587,9 +1874,10 @@ func NotOneOf[T comparable](vals []T) *RuleSet {
 func RequiredUnless(vals ...any) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.RequiredUnless",
-		MessageFunc: func(rs *RuleSet) string {
-			return MandatoryFieldMsg
+		CodeFunc: func(rs *RuleSet) string {
+			return RequiredCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			if HasValue(rs.FieldValue) {
 				return true
@@ -609,9 +1897,11 @@ func RequiredUnless(vals ...any) *RuleSet {
 func After(dt time.Time) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.After",
-		MessageFunc: func(rs *RuleSet) string {
-			return IlogicalDatesMsg
+		Params:   map[string]any{"date": dt},
+		CodeFunc: func(rs *RuleSet) string {
+			return AfterCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			switch val := rs.FieldValue.(type) {
 			case time.Time:
@@ -628,9 +1918,11 @@ func After(dt time.Time) *RuleSet {
 func NotAfter(dt time.Time) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.NotAfter",
-		MessageFunc: func(rs *RuleSet) string {
-			return IlogicalDatesMsg
+		Params:   map[string]any{"date": dt},
+		CodeFunc: func(rs *RuleSet) string {
+			return NotAfterCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			switch val := rs.FieldValue.(type) {
 			case time.Time:
@@ -647,9 +1939,11 @@ func NotAfter(dt time.Time) *RuleSet {
 func Before(dt time.Time) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.Before",
-		MessageFunc: func(rs *RuleSet) string {
-			return IlogicalDatesMsg
+		Params:   map[string]any{"date": dt},
+		CodeFunc: func(rs *RuleSet) string {
+			return BeforeCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			switch val := rs.FieldValue.(type) {
 			case time.Time:
@@ -666,9 +1960,11 @@ func Before(dt time.Time) *RuleSet {
 func NotBefore(dt time.Time) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.NotBefore",
-		MessageFunc: func(rs *RuleSet) string {
-			return IlogicalDatesMsg
+		Params:   map[string]any{"date": dt},
+		CodeFunc: func(rs *RuleSet) string {
+			return NotBeforeCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			switch val := rs.FieldValue.(type) {
 			case time.Time:
@@ -687,9 +1983,11 @@ func NotBefore(dt time.Time) *RuleSet {
 func MaxDaysRange(dt time.Time, maxDays int) *RuleSet {
 	return &RuleSet{
 		RuleName: "safe.MaxDaysRange",
-		MessageFunc: func(rs *RuleSet) string {
-			return MaxDaysRangeMsg(maxDays)
+		Params:   map[string]any{"date": dt, "max_days": maxDays},
+		CodeFunc: func(rs *RuleSet) string {
+			return MaxDaysRangeCode
 		},
+		MessageFunc: defaultMessage,
 		ValidateFunc: func(rs *RuleSet) bool {
 			switch val := rs.FieldValue.(type) {
 			case time.Time:
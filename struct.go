@@ -0,0 +1,297 @@
+package safe
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Validates a struct (or a pointer to a struct) according to the `safe` tags of its fields.
+//
+// Each tag is mapped onto the rule constructors of this package, and the `json` tag of the
+// field is used as the key in ErrorMessages. When there is no `json` tag, the field name is used instead.
+// Fields ignored by encoding/json, with `json:"-"`, are skipped, unless they have a `safe` tag.
+// The `label` tag, when given, becomes the Field.Label.
+//
+// Example usage:
+//
+//	type User struct {
+//		Email    string `json:"email" label:"E-mail" safe:"required,email,max=128"`
+//		Password string `json:"password" safe:"required,strongpassword"`
+//		Role     string `json:"role" safe:"required,oneof=admin|staff|guest"`
+//		Age      int    `json:"age" safe:"min=18"`
+//	}
+//
+//	errors, ok := safe.ValidateStruct(user)
+//
+// Please refer to safe.ParseTag for the tag syntax.
+//
+// It returns the same values as safe.Validate.
+//
+// ValidateStruct panics if v is not a struct nor a pointer to a struct, or if any of its tags is malformed.
+func ValidateStruct(v any) (ErrorMessages, bool) {
+	return Validate(StructFields(v))
+}
+
+// Builds safe.Fields out of the `safe` tags of a struct (or a pointer to a struct).
+//
+// Fields without a `safe` tag are ignored. Fields of embedded structs are promoted, just like encoding/json does.
+//
+// Fields holding other structs become Field.Children, and slices or arrays of structs become Field.Elements,
+// so that error messages are keyed like "address.cep" and "items[2].sku". They don't need a `safe` tag,
+// unless the nested value itself has rules, as in `safe:"required"`.
+//
+// Nil pointers to other values are validated as if they were the zero value of the type they point to.
+//
+// This is useful when some rules can't be expressed in tags, but most of them can:
+//
+//	fields := safe.StructFields(user)
+//	fields.SetRules("email", safe.Rules{safe.Email(), safe.RequiredUnless(user.Phone)})
+//	errors, ok := safe.Validate(fields)
+//
+// StructFields panics if v is not a struct nor a pointer to a struct, or if any of its tags is malformed.
+func StructFields(v any) Fields {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("safe: expected a struct or a pointer to a struct, got %T", v))
+	}
+
+	specs, err := structSpecsOf(rv.Type())
+	if err != nil {
+		panic(err)
+	}
+
+	fields := make(Fields, 0, len(specs))
+	for _, spec := range specs {
+		rules := make(Rules, 0, len(spec.rules))
+		for _, newRule := range spec.rules {
+			rules = append(rules, newRule())
+		}
+
+		field := &Field{Name: spec.name, Label: spec.label, Rules: rules}
+
+		switch spec.nested {
+		case nestedStruct, nestedList:
+			fv := structFieldRawValue(rv, spec)
+			field.Value = fv.Interface()
+			if spec.nested == nestedStruct {
+				field.Children = nestedStructFields(fv)
+				break
+			}
+			if fv.Kind() == reflect.Slice && fv.IsNil() {
+				break
+			}
+			field.Elements = make([]Fields, fv.Len())
+			for i := range field.Elements {
+				field.Elements[i] = nestedStructFields(fv.Index(i))
+			}
+		default:
+			field.Value = structFieldValue(rv, spec)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+func nestedStructFields(v reflect.Value) Fields {
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil
+	}
+	return StructFields(v.Interface())
+}
+
+type nestedKind int
+
+const (
+	notNested nestedKind = iota
+	nestedStruct
+	nestedList
+)
+
+// What is known about a tagged struct field, parsed once per struct type.
+type structFieldSpec struct {
+	name    string
+	label   string
+	index   []int
+	typ     reflect.Type // the type of the field, or the type it points to
+	rawType reflect.Type
+	nested  nestedKind
+	rules   []func() *RuleSet
+}
+
+var structSpecsCache sync.Map // map[reflect.Type][]*structFieldSpec
+
+func structSpecsOf(t reflect.Type) ([]*structFieldSpec, error) {
+	if specs, ok := structSpecsCache.Load(t); ok {
+		return specs.([]*structFieldSpec), nil
+	}
+
+	specs, err := buildStructSpecs(t, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	structSpecsCache.Store(t, specs)
+	return specs, nil
+}
+
+func buildStructSpecs(t reflect.Type, parentIndex []int) ([]*structFieldSpec, error) {
+	var specs []*structFieldSpec
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if ignoredByJSON(sf) {
+			continue
+		}
+
+		index := append(append([]int{}, parentIndex...), i)
+		jsonName := jsonFieldName(sf)
+
+		if sf.Anonymous && jsonName == "" {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				promoted, err := buildStructSpecs(embedded, index)
+				if err != nil {
+					return nil, err
+				}
+				specs = append(specs, promoted...)
+				continue
+			}
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, hasTag := sf.Tag.Lookup("safe")
+		nested := nestedKindOf(sf.Type)
+		if !hasTag && nested == notNested {
+			continue
+		}
+
+		tagRules, err := ParseTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("safe: field %s.%s: %w", t.Name(), sf.Name, err)
+		}
+
+		fieldType := sf.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		spec := &structFieldSpec{name: jsonName, label: sf.Tag.Get("label"), index: index, typ: fieldType, rawType: sf.Type, nested: nested}
+		if spec.name == "" {
+			spec.name = sf.Name
+		}
+
+		for _, tagRule := range tagRules {
+			newRule, err := tagRuleSet(tagRule, fieldType)
+			if err != nil {
+				return nil, fmt.Errorf("safe: field %s.%s: %w", t.Name(), sf.Name, err)
+			}
+			spec.rules = append(spec.rules, newRule)
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// Tells if a field of type t holds other tagged structs, either directly or as a list.
+func nestedKindOf(t reflect.Type) nestedKind {
+	kind := nestedStruct
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		kind = nestedList
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || !hasSafeTags(t, make(map[reflect.Type]bool)) {
+		return notNested
+	}
+
+	return kind
+}
+
+// Tells if a struct type has `safe` tags, be it in its own fields or in the structs it holds.
+func hasSafeTags(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if t == timeType || visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if _, ok := sf.Tag.Lookup("safe"); ok && sf.IsExported() {
+			return true
+		}
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && (sf.IsExported() || sf.Anonymous) && !ignoredByJSON(sf) && hasSafeTags(ft, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Tells if a field is ignored by encoding/json, with `json:"-"`, and has no `safe` tag of its own.
+func ignoredByJSON(sf reflect.StructField) bool {
+	_, hasTag := sf.Tag.Lookup("safe")
+	return sf.Tag.Get("json") == "-" && !hasTag
+}
+
+// Returns the name given to a field by its `json` tag, if any.
+func jsonFieldName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// Returns the value of a struct field, walking through embedded pointers.
+//
+// When a pointer in the way is nil, the zero value of the field type is returned.
+func structFieldValue(rv reflect.Value, spec *structFieldSpec) any {
+	v := structFieldRawValue(rv, spec)
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Zero(spec.typ).Interface()
+		}
+		v = v.Elem()
+	}
+
+	return v.Interface()
+}
+
+// Same as structFieldValue, but pointers are not followed.
+func structFieldRawValue(rv reflect.Value, spec *structFieldSpec) reflect.Value {
+	v, err := rv.FieldByIndexErr(spec.index)
+	if err != nil {
+		return reflect.Zero(spec.rawType)
+	}
+	return v
+}
@@ -0,0 +1,280 @@
+package safe
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A single rule parsed from a `safe` struct tag.
+//
+// For instance, the tag `safe:"required,max=128"` is parsed into two TagRules:
+// {Name: "required"} and {Name: "max", Param: "128"}.
+type TagRule struct {
+	Name  string
+	Param string
+}
+
+func (tr TagRule) String() string {
+	if tr.Param == "" {
+		return tr.Name
+	}
+	return tr.Name + "=" + tr.Param
+}
+
+type tagParamKind int
+
+const (
+	tagNoParam tagParamKind = iota
+	tagIntParam
+	tagListParam
+	tagRegexParam
+)
+
+// Every rule name that may be used inside a `safe` struct tag, and the kind of parameter it expects.
+var tagRules = map[string]tagParamKind{
+	"required":       tagNoParam,
+	"true":           tagNoParam,
+	"false":          tagNoParam,
+	"email":          tagNoParam,
+	"phone":          tagNoParam,
+	"mobilephone":    tagNoParam,
+	"landlinephone":  tagNoParam,
+	"cpf":            tagNoParam,
+	"cnpj":           tagNoParam,
+	"cpfcnpj":        tagNoParam,
+	"pis":            tagNoParam,
+	"tituloeleitor":  tagNoParam,
+	"cnh":            tagNoParam,
+	"cns":            tagNoParam,
+	"cep":            tagNoParam,
+	"strongpassword": tagNoParam,
+	"uuid":           tagNoParam,
+	"pixkey":         tagNoParam,
+	"plate":          tagNoParam,
+	"renavam":        tagNoParam,
+	"vin":            tagNoParam,
+	"vincheckdigit":  tagNoParam,
+	"cardnumber":     tagNoParam,
+	"cardexpiry":     tagNoParam,
+	"iban":           tagNoParam,
+	"bic":            tagNoParam,
+	"min":            tagIntParam,
+	"max":            tagIntParam,
+	"oneof":          tagListParam,
+	"notoneof":       tagListParam,
+	"match":          tagRegexParam,
+}
+
+// Parses the value of a `safe` struct tag.
+//
+// Rules are separated by commas, and parameters are given after an equals sign.
+// List parameters, as in oneof and notoneof, are separated by pipes.
+//
+// Example:
+//
+//	type User struct {
+//		Email string `json:"email" safe:"required,email,max=128"`
+//		Role  string `json:"role" safe:"oneof=admin|staff|guest"`
+//		Code  string `json:"code" safe:"match=^\\d{2\\,4}$"`
+//	}
+//
+// A literal comma inside a parameter must be escaped with a backslash, like in the Code field above.
+//
+// An error is returned when a rule is unknown or when its parameter is missing or malformed.
+func ParseTag(tag string) ([]TagRule, error) {
+	var rules []TagRule
+
+	for _, item := range splitTag(tag) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, param, hasParam := strings.Cut(item, "=")
+		rule := TagRule{Name: strings.TrimSpace(name), Param: param}
+
+		kind, ok := tagRules[rule.Name]
+		if !ok {
+			return nil, fmt.Errorf("safe: unknown rule %q in tag %q", rule.Name, tag)
+		}
+
+		switch kind {
+		case tagNoParam:
+			if hasParam {
+				return nil, fmt.Errorf("safe: rule %q does not take a parameter", rule.Name)
+			}
+		case tagIntParam:
+			if _, err := strconv.Atoi(rule.Param); err != nil {
+				return nil, fmt.Errorf("safe: rule %q expects an integer parameter, got %q", rule.Name, rule.Param)
+			}
+		case tagListParam:
+			if rule.Param == "" {
+				return nil, fmt.Errorf("safe: rule %q expects a list of values separated by |", rule.Name)
+			}
+		case tagRegexParam:
+			if _, err := regexp.Compile(rule.Param); err != nil {
+				return nil, fmt.Errorf("safe: rule %q expects a valid regex: %v", rule.Name, err)
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Splits a tag on commas, except for the ones escaped with a backslash.
+func splitTag(tag string) []string {
+	var items []string
+	item := &strings.Builder{}
+
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			item.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			items = append(items, item.String())
+			item.Reset()
+		default:
+			item.WriteByte(tag[i])
+		}
+	}
+
+	return append(items, item.String())
+}
+
+// Returns a constructor for the RuleSet described by a TagRule.
+//
+// The field type is needed to convert the parameters of oneof and notoneof,
+// so that they can be compared against the field value.
+func tagRuleSet(rule TagRule, fieldType reflect.Type) (func() *RuleSet, error) {
+	switch rule.Name {
+	case "required":
+		return Required, nil
+	case "true":
+		return True, nil
+	case "false":
+		return False, nil
+	case "email":
+		return Email, nil
+	case "phone":
+		return Phone, nil
+	case "mobilephone":
+		return MobilePhone, nil
+	case "landlinephone":
+		return LandlinePhone, nil
+	case "cpf":
+		return Cpf, nil
+	case "cnpj":
+		return Cnpj, nil
+	case "cpfcnpj":
+		return CpfCnpj, nil
+	case "pis":
+		return Pis, nil
+	case "tituloeleitor":
+		return TituloEleitor, nil
+	case "cnh":
+		return Cnh, nil
+	case "cns":
+		return Cns, nil
+	case "cep":
+		return CEP, nil
+	case "strongpassword":
+		return StrongPassword, nil
+	case "uuid":
+		return UUIDstr, nil
+	case "pixkey":
+		return PixKey, nil
+	case "plate":
+		return Plate, nil
+	case "renavam":
+		return Renavam, nil
+	case "vin":
+		return VIN, nil
+	case "vincheckdigit":
+		return VINWithCheckDigit, nil
+	case "cardnumber":
+		return func() *RuleSet { return CardNumber() }, nil
+	case "cardexpiry":
+		return CardExpiry, nil
+	case "iban":
+		return func() *RuleSet { return IBAN() }, nil
+	case "bic":
+		return BIC, nil
+	case "min", "max":
+		n, err := strconv.Atoi(rule.Param)
+		if err != nil {
+			return nil, err
+		}
+		if rule.Name == "min" {
+			return func() *RuleSet { return Min(n) }, nil
+		}
+		return func() *RuleSet { return Max(n) }, nil
+	case "oneof", "notoneof":
+		vals, err := tagListValues(rule.Param, fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("safe: rule %q: %v", rule.Name, err)
+		}
+		if rule.Name == "oneof" {
+			return func() *RuleSet { return OneOf(vals) }, nil
+		}
+		return func() *RuleSet { return NotOneOf(vals) }, nil
+	case "match":
+		regex, err := regexp.Compile(rule.Param)
+		if err != nil {
+			return nil, err
+		}
+		return func() *RuleSet { return Match(regex) }, nil
+	}
+
+	return nil, fmt.Errorf("safe: unknown rule %q", rule.Name)
+}
+
+// Converts a list parameter like "a|b|c" into values of the given type.
+func tagListValues(param string, t reflect.Type) ([]any, error) {
+	parts := strings.Split(param, "|")
+	vals := make([]any, 0, len(parts))
+
+	for _, part := range parts {
+		v := reflect.New(t).Elem()
+
+		switch t.Kind() {
+		case reflect.String:
+			v.SetString(part)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(part)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a bool", part)
+			}
+			v.SetBool(b)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(part, 10, t.Bits())
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a valid %s", part, t)
+			}
+			v.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n, err := strconv.ParseUint(part, 10, t.Bits())
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a valid %s", part, t)
+			}
+			v.SetUint(n)
+		case reflect.Float32, reflect.Float64:
+			f, err := strconv.ParseFloat(part, t.Bits())
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a valid %s", part, t)
+			}
+			v.SetFloat(f)
+		default:
+			return nil, fmt.Errorf("fields of type %s are not supported", t)
+		}
+
+		vals = append(vals, v.Interface())
+	}
+
+	return vals, nil
+}
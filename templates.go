@@ -0,0 +1,48 @@
+package safe
+
+import "fmt"
+
+// Everything a message may need to know about a broken rule.
+//
+// It is available to message templates as placeholders:
+//
+//	{field}: the name of the field, or its path in case of nested fields, as in "address.cep"
+//	{label}: the label of the field, or its name when there is no label
+//	{value}: the value of the field
+//
+// Along with the parameters of the rule, like {min} for safe.Min and {max} for safe.Max.
+type MessageContext struct {
+	Field  string
+	Label  string
+	Value  any
+	Locale string
+	Rule   string
+	Code   string
+	Params map[string]any
+}
+
+// Returns the MessageContext of the RuleSet, according to the field being validated.
+func (rs *RuleSet) Context() MessageContext {
+	return MessageContext{
+		Field:  rs.FieldName,
+		Label:  rs.FieldLabel,
+		Value:  rs.FieldValue,
+		Locale: rs.Locale,
+		Rule:   rs.RuleName,
+		Code:   rs.Code(),
+		Params: rs.Params,
+	}
+}
+
+// Replaces the placeholders of a message template, like "O campo {label} deve ter no máximo {max} caracteres".
+func (ctx MessageContext) Format(template string) string {
+	params := make(map[string]any, len(ctx.Params)+3)
+	for name, val := range ctx.Params {
+		params[name] = val
+	}
+	params["field"] = ctx.Field
+	params["label"] = ctx.Label
+	params["value"] = fmt.Sprint(ctx.Value)
+
+	return interpolate(template, params)
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cayo-rodrigues/safe"
+)
+
+func TestCheckSuccess(t *testing.T) {
+	user := newSampleUser()
+	user.Phone = samplePhoneWithDDD
+
+	if err := safe.Check(sampleFields(user)); err != nil {
+		t.Errorf("User should be valid. Got: %v", err)
+	}
+	if err := safe.CheckAll(sampleFields(user)); err != nil {
+		t.Errorf("User should be valid. Got: %v", err)
+	}
+}
+
+func TestCheckFailure(t *testing.T) {
+	user := newSampleUser()
+	user.Phone = samplePhoneWithDDD
+	user.Name = "pe"
+	user.Age = 17
+	user.Job = "pepsiman"
+	user.sampleAddress.Cep = ""
+
+	fields := sampleFields(user)
+	err := safe.Check(fields)
+
+	var validationErrs safe.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Check should return safe.ValidationErrors. Got: %#v", err)
+	}
+
+	expected := []safe.ValidationError{
+		{Field: "name", Rule: "safe.Min", Code: safe.MinCharsCode, Message: safe.MinCharsMsg(3)},
+		{Field: "age", Rule: "safe.Min", Code: safe.MinValueCode, Message: safe.MinValueMsg(18)},
+		{Field: "job", Rule: "safe.OneOf", Code: safe.UnacceptableValueCode, Message: safe.UnacceptableValueMsg},
+		{Field: "address_city", Rule: "safe.RequiredUnless", Code: safe.RequiredCode, Message: safe.MandatoryFieldMsg},
+		{Field: "address_state", Rule: "safe.RequiredUnless", Code: safe.RequiredCode, Message: safe.MandatoryFieldMsg},
+	}
+
+	if len(validationErrs) != len(expected) {
+		t.Fatalf("Expected %d errors. Got: %v", len(expected), validationErrs)
+	}
+
+	for i, e := range validationErrs {
+		if e.Field != expected[i].Field || e.Rule != expected[i].Rule || e.Code != expected[i].Code || e.Message != expected[i].Message {
+			t.Errorf("Expected error %+v. Got: %+v", expected[i], *e)
+		}
+	}
+
+	if min := validationErrs[1].Params["min"]; min != 18 {
+		t.Errorf("Expected age error to carry the min param. Got: %v", validationErrs[1].Params)
+	}
+
+	var firstErr *safe.ValidationError
+	if !errors.As(err, &firstErr) || firstErr.Field != "name" {
+		t.Errorf("errors.As should find the first ValidationError. Got: %v", firstErr)
+	}
+
+	errMsgs, _ := safe.Validate(fields)
+	derivedMsgs := validationErrs.ErrorMessages()
+	if len(errMsgs) != len(derivedMsgs) {
+		t.Fatalf("ErrorMessages should be derivable from ValidationErrors.\nExpected: %s.\nGot: %s.", errMsgs, derivedMsgs)
+	}
+	for name, msg := range errMsgs {
+		if derivedMsgs[name] != msg {
+			t.Errorf("Expected %s error message: \"%s\". Got: \"%s\"", name, msg, derivedMsgs[name])
+		}
+	}
+
+	if _, err := json.Marshal(validationErrs); err != nil {
+		t.Errorf("ValidationErrors should be marshalable to json. Got: %v", err)
+	}
+}
+
+func TestCheckAllFailure(t *testing.T) {
+	fields := safe.Fields{
+		{
+			Name:  "password",
+			Value: "pepsi",
+			Rules: safe.Rules{safe.Min(8), safe.StrongPassword()},
+		},
+	}
+
+	err := safe.CheckAll(fields)
+
+	var validationErrs safe.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("CheckAll should return safe.ValidationErrors. Got: %#v", err)
+	}
+
+	codes := []string{safe.MinCharsCode, safe.WeakPasswordCode}
+	if len(validationErrs) != len(codes) {
+		t.Fatalf("Expected codes %v. Got: %v", codes, validationErrs)
+	}
+	for i, code := range codes {
+		if validationErrs[i].Code != code {
+			t.Errorf("Expected code %s. Got: %s", code, validationErrs[i].Code)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cayo-rodrigues/safe"
+)
+
+func localeSampleFields() safe.Fields {
+	return safe.Fields{
+		{Name: "name", Value: "", Rules: safe.Rules{safe.Required()}},
+		{Name: "nickname", Value: "pe", Rules: safe.Rules{safe.Min(3)}},
+		{Name: "initial", Value: "pe", Rules: safe.Rules{safe.Max(1)}},
+		{Name: "email", Value: "pepsiman", Rules: safe.Rules{safe.Email().WithMessage("custom")}},
+	}
+}
+
+func TestValidateWithLocale(t *testing.T) {
+	expectedByLocale := map[string]safe.ErrorMessages{
+		"pt-BR": {
+			"name":     "Campo obrigatório",
+			"nickname": "Mínimo de 3 caracteres",
+			"initial":  "Máximo de 1 caractere",
+			"email":    "custom",
+		},
+		"en-US": {
+			"name":     "Required field",
+			"nickname": "At least 3 characters",
+			"initial":  "At most 1 character",
+			"email":    "custom",
+		},
+		"es": {
+			"name":     "Campo obligatorio",
+			"nickname": "Mínimo de 3 caracteres",
+			"initial":  "Máximo de 1 carácter",
+			"email":    "custom",
+		},
+		"xx": {
+			"name":     safe.MandatoryFieldMsg,
+			"nickname": safe.MinCharsMsg(3),
+			"initial":  safe.MaxCharsMsg(1),
+			"email":    "custom",
+		},
+	}
+
+	for locale, expectedErrs := range expectedByLocale {
+		errs, ok := safe.ValidateWithLocale(localeSampleFields(), locale)
+		if ok {
+			t.Fatalf("fields should not be valid")
+		}
+
+		for name, expectedMsg := range expectedErrs {
+			if msg := errs[name]; msg != expectedMsg {
+				t.Errorf("[%s] Expected %s error message: \"%s\". Got: \"%s\"", locale, name, expectedMsg, msg)
+			}
+		}
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	safe.RegisterLocale("fr", safe.Catalog{
+		safe.RequiredCode: {Other: "Champ obligatoire"},
+		safe.MinCharsCode: {One: "Au moins {min} caractère", Other: "Au moins {min} caractères", Count: "min"},
+	})
+
+	errs, _ := safe.ValidateWithLocale(localeSampleFields(), "fr-CA")
+
+	expectedErrs := safe.ErrorMessages{
+		"name":     "Champ obligatoire",
+		"nickname": "Au moins 3 caractères",
+		"initial":  safe.MaxCharsMsg(1), // falls back to the default locale
+	}
+	for name, expectedMsg := range expectedErrs {
+		if msg := errs[name]; msg != expectedMsg {
+			t.Errorf("Expected %s error message: \"%s\". Got: \"%s\"", name, expectedMsg, msg)
+		}
+	}
+}
+
+func TestLoadLocaleFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "it.json")
+	content := `{
+		"required": "Campo obbligatorio",
+		"max_chars": {"one": "Al massimo {max} carattere", "other": "Al massimo {max} caratteri", "count": "max"}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := safe.LoadLocaleFile("it", path); err != nil {
+		t.Fatalf("locale file should be loaded. Got: %v", err)
+	}
+
+	errs, _ := safe.ValidateWithLocale(localeSampleFields(), "it")
+
+	if msg := errs["name"]; msg != "Campo obbligatorio" {
+		t.Errorf("Expected name error message: \"Campo obbligatorio\". Got: \"%s\"", msg)
+	}
+	if msg := errs["initial"]; msg != "Al massimo 1 carattere" {
+		t.Errorf("Expected initial error message: \"Al massimo 1 carattere\". Got: \"%s\"", msg)
+	}
+
+	if err := safe.LoadLocaleFile("it", filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("missing locale file should not be loaded")
+	}
+}
+
+func TestLocaleFromAcceptLanguage(t *testing.T) {
+	headers := map[string]string{
+		"":                                 "pt-BR",
+		"en-US,en;q=0.9":                   "en",
+		"es-AR,es;q=0.9,en;q=0.8":          "es",
+		"de-DE,de;q=0.9,en;q=0.5,es;q=0.8": "es",
+		"pt-PT":                            "pt-BR",
+		"de, *;q=0.5":                      "pt-BR",
+		"en;q=0, es":                       "es",
+	}
+
+	for header, expected := range headers {
+		if locale := safe.LocaleFromAcceptLanguage(header); locale != expected {
+			t.Errorf("Expected locale %s for header %q. Got: %s", expected, header, locale)
+		}
+	}
+}
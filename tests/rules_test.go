@@ -1,7 +1,12 @@
 package tests
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,7 +24,31 @@ func TestRequiredRule(t *testing.T) {
 		{Val: 0},
 		{Val: nil},
 	}
-	okValues := []any{"a", -1}
+	okValues := []any{"a", -1, int64(1), uint8(1), json.Number("0.5"), big.NewInt(-1), big.NewRat(1, 3)}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.MandatoryFieldMsg)
+
+	type cents int64
+
+	invalidValues = []*invalidValue{
+		{Val: int64(0)},
+		{Val: uint8(0)},
+		{Val: cents(0)},
+		{Val: json.Number("0.00")},
+		{Val: json.Number("")},
+		{Val: big.NewInt(0)},
+		{Val: (*big.Int)(nil)},
+		{Val: new(big.Rat)},
+	}
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.MandatoryFieldMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	invalidValues = []*invalidValue{
+		{Val: []string(nil)},
+		{Val: map[string]int(nil)},
+		{Val: (*string)(nil)},
+	}
+	okValues = []any{[]string{}, map[string]int{}, []string{""}, new(string)}
 
 	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.MandatoryFieldMsg)
 	testFieldWithOkValues(fieldData, okValues, t)
@@ -55,6 +84,14 @@ func TestPhoneRule(t *testing.T) {
 		{Val: " "},
 		{Val: "123"},
 		{Val: "999445678"},
+		{Val: "(20) 99944-5678"},
+		{Val: "(23) 3344-5678"},
+		{Val: "(35) 89944-5678"},
+		{Val: "(35) 8944-5678"},
+		{Val: "+1 35 99944-5678"},
+		{Val: "+55 99988-7766"},
+		{Val: "(35) 99944-5678 ramal 2"},
+		{Val: 35999445678},
 	}
 	okValues := []any{
 		"(35) 99944-5678",
@@ -62,12 +99,70 @@ func TestPhoneRule(t *testing.T) {
 		"35999445678",
 		"5535999445678",
 		"+5535999445678",
+		"+55 (35) 3344-5678",
+		"0055 11 99999-8888",
+		"035 3344-5678",
+	}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestMobilePhoneRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "phone",
+		Rules: safe.Rules{safe.MobilePhone()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: "(35) 3344-5678"},
+		{Val: "(20) 99944-5678"},
+	}
+	okValues := []any{"(35) 99944-5678", "+5511999998888"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestLandlinePhoneRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "phone",
+		Rules: safe.Rules{safe.LandlinePhone()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: "(35) 99944-5678"},
+		{Val: "(35) 6344-5678"},
 	}
+	okValues := []any{"(35) 3344-5678", "+55 61 2020-1010"}
 
 	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
 	testFieldWithOkValues(fieldData, okValues, t)
 }
 
+func TestNormalizePhone(t *testing.T) {
+	expectedPhones := map[string]string{
+		"(11) 99999-8888":     "+5511999998888",
+		"+55 11 99999-8888":   "+5511999998888",
+		"5511999998888":       "+5511999998888",
+		"0055 (35) 3344-5678": "+553533445678",
+		"035 3344-5678":       "+553533445678",
+		"(20) 99999-8888":     "",
+		"99999-8888":          "",
+	}
+
+	for phone, expected := range expectedPhones {
+		normalized, ok := safe.NormalizePhone(phone)
+		if normalized != expected || ok != (expected != "") {
+			t.Errorf("Expected %q to be normalized to %q. Got: %q, %v", phone, expected, normalized, ok)
+		}
+	}
+
+	if !safe.IsValidDDD("35") || safe.IsValidDDD("20") {
+		t.Errorf("Only area codes in use should be valid")
+	}
+}
+
 func TestCpfRule(t *testing.T) {
 	fieldData := &safe.Field{
 		Name:  "cpf",
@@ -80,6 +175,10 @@ func TestCpfRule(t *testing.T) {
 		{Val: "9004912401"},
 		{Val: "308.305.800.42"},
 		{Val: "393z546.320-09"},
+		{Val: "393.546.320-08"},
+		{Val: "123.456.789-00"},
+		{Val: "111.111.111-11"},
+		{Val: "00000000000"},
 	}
 	okValues := []any{
 		"11421499002",
@@ -90,6 +189,22 @@ func TestCpfRule(t *testing.T) {
 	testFieldWithOkValues(fieldData, okValues, t)
 }
 
+func TestCpfCheckDigits(t *testing.T) {
+	expectedDigits := map[string]string{
+		"393546320": "09",
+		"114214990": "02",
+		"738691910": "74",
+		"12345678":  "",
+		"39354632a": "",
+	}
+
+	for base, expected := range expectedDigits {
+		if digits := safe.CpfCheckDigits(base); digits != expected {
+			t.Errorf("Expected check digits of %q: %q. Got: %q", base, expected, digits)
+		}
+	}
+}
+
 func TestCnpjRule(t *testing.T) {
 	fieldData := &safe.Field{
 		Name:  "cnpj",
@@ -102,16 +217,40 @@ func TestCnpjRule(t *testing.T) {
 		{Val: "575067450001"},
 		{Val: "74.082.201/0001-aa"},
 		{Val: "74.082.201b0001-86"},
+		{Val: "45.769.852/0001-87"},
+		{Val: "00.000.000/0000-00"},
+		{Val: "12.ABC.345/01DE-36"},
+		{Val: "12.ABC.345/01DE-3A"},
+		{Val: "12.ÁBC.345/01DE-35"},
 	}
 	okValues := []any{
 		"45.769.852/0001-86",
 		"11789602000196",
+		"12.ABC.345/01DE-35",
+		"12ABC34501DE35",
+		"12.abc.345/01de-35",
 	}
 
 	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
 	testFieldWithOkValues(fieldData, okValues, t)
 }
 
+func TestCnpjCheckDigits(t *testing.T) {
+	expectedDigits := map[string]string{
+		"457698520001": "86",
+		"117896020001": "96",
+		"12ABC34501DE": "35",
+		"12abc34501de": "",
+		"12ABC34501D":  "",
+	}
+
+	for base, expected := range expectedDigits {
+		if digits := safe.CnpjCheckDigits(base); digits != expected {
+			t.Errorf("Expected check digits of %q: %q. Got: %q", base, expected, digits)
+		}
+	}
+}
+
 func TestCpfCnpjRule(t *testing.T) {
 	fieldData := &safe.Field{
 		Name:  "cpf/cnpj",
@@ -126,12 +265,16 @@ func TestCpfCnpjRule(t *testing.T) {
 		{Val: "44504044000127272"},
 		{Val: "543368000001aa"},
 		{Val: "43.549a814/0001-92"},
+		{Val: "123.456.789-00"},
+		{Val: "222.222.222-22"},
+		{Val: "99.379.672/0001-18"},
 	}
 	okValues := []any{
 		"99.379.672/0001-17",
 		"68840265000131",
 		"90007645058",
 		"738.691.910-74",
+		"12.ABC.345/01DE-35",
 	}
 
 	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
@@ -161,6 +304,60 @@ func TestCEPRule(t *testing.T) {
 	testFieldWithOkValues(fieldData, okValues, t)
 }
 
+func TestCEPInStateRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "cep",
+		Rules: safe.Rules{safe.CEPInState("SP")},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: "123", ExpectedErrMsg: safe.InvalidFormatMsg},
+		{Val: "00999-999", ExpectedErrMsg: "CEP não pertence ao estado SP"},
+		{Val: "20040-002", ExpectedErrMsg: "CEP não pertence ao estado SP"},
+		{Val: "37500000", ExpectedErrMsg: "CEP não pertence ao estado SP"},
+	}
+	okValues := []any{"", "01310-100", "19999999"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	fieldData.Rules = safe.Rules{safe.CEPInState("xx")}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "01310-100"}}, t, "CEP não pertence ao estado xx")
+}
+
+func TestUFFromCEP(t *testing.T) {
+	expectedUFs := map[string]string{
+		"01310-100": "SP",
+		"20040-002": "RJ",
+		"29010-000": "ES",
+		"37500-000": "MG",
+		"40010-000": "BA",
+		"49001-084": "SE",
+		"68900-000": "AP",
+		"69301-000": "RR",
+		"69400-000": "AM",
+		"69900-000": "AC",
+		"70040-010": "DF",
+		"72800-000": "GO",
+		"73000-000": "DF",
+		"74000-000": "GO",
+		"76801-000": "RO",
+		"77001286":  "TO",
+		"79002-000": "MS",
+		"88010-000": "SC",
+		"90010-000": "RS",
+		"00999-999": "",
+		"123":       "",
+	}
+
+	for cep, expected := range expectedUFs {
+		uf, ok := safe.UFFromCEP(cep)
+		if uf != expected || ok != (expected != "") {
+			t.Errorf("Expected uf of %q: %q. Got: %q, %v", cep, expected, uf, ok)
+		}
+	}
+}
+
 func TestStrongPasswordRule(t *testing.T) {
 	fieldData := &safe.Field{
 		Name:  "strong_password",
@@ -318,6 +515,26 @@ func TestMinRule(t *testing.T) {
 	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.MinValueMsg(minValue))
 	testFieldWithOkValues(fieldData, okValues, t)
 
+	type cents int64
+
+	invalidValues = []*invalidValue{
+		{Val: int8(4)},
+		{Val: int64(-6)},
+		{Val: uint8(4)},
+		{Val: uint64(0)},
+		{Val: float32(4.9)},
+		{Val: cents(4)},
+		{Val: json.Number("4.99")},
+		{Val: json.Number("abc")},
+		{Val: big.NewInt(4)},
+		{Val: big.NewRat(49, 10)},
+		{Val: math.NaN()},
+	}
+	okValues = []any{int16(5), int32(6), uint(5), uint16(100), cents(5), json.Number("5"), json.Number("5e2"), big.NewInt(5), big.NewRat(11, 2)}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.MinValueMsg(minValue))
+	testFieldWithOkValues(fieldData, okValues, t)
+
 	invalidValues = []*invalidValue{
 		{Val: "1234"},
 		{Val: "1   "},
@@ -348,6 +565,22 @@ func TestMaxRule(t *testing.T) {
 	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.MaxValueMsg(maxValue))
 	testFieldWithOkValues(fieldData, okValues, t)
 
+	type rating uint8
+
+	invalidValues = []*invalidValue{
+		{Val: int64(2)},
+		{Val: uint8(2)},
+		{Val: rating(5)},
+		{Val: json.Number("1.01")},
+		{Val: big.NewInt(2)},
+		{Val: big.NewRat(3, 2)},
+		{Val: math.Inf(1)},
+	}
+	okValues = []any{int64(-2), uint8(1), rating(0), json.Number("1.00"), big.NewInt(1), big.NewRat(1, 2)}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.MaxValueMsg(maxValue))
+	testFieldWithOkValues(fieldData, okValues, t)
+
 	invalidValues = []*invalidValue{
 		{Val: "1234"},
 		{Val: "1   "},
@@ -360,6 +593,91 @@ func TestMaxRule(t *testing.T) {
 	testFieldWithOkValues(fieldData, okValues, t)
 }
 
+func TestBetweenRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "discount",
+		Rules: safe.Rules{safe.Between(0.5, 99.99)},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: 0.49},
+		{Val: 100},
+		{Val: 99.991},
+		{Val: json.Number("0.4999")},
+		{Val: "50"},
+		{Val: nil},
+	}
+	okValues := []any{0.5, 99.99, 1, uint8(50), float32(99.99), json.Number("0.50"), big.NewRat(1, 2)}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.BetweenMsg(0.5, 99.99))
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	fieldData.Rules = safe.Rules{safe.BetweenExclusive(0.5, 99.99)}
+
+	invalidValues = []*invalidValue{{Val: 0.5}, {Val: 99.99}, {Val: 100}}
+	okValues = []any{0.51, 99.98, 1}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.BetweenExclusiveMsg(0.5, 99.99))
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestGreaterAndLessThanRules(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "price",
+		Rules: safe.Rules{safe.GreaterThan(0)},
+	}
+
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: 0}, {Val: 0.0}, {Val: -0.01}, {Val: json.Number("0.00")}}, t, safe.GreaterThanMsg(0))
+	testFieldWithOkValues(fieldData, []any{0.01, 1, uint64(1), big.NewInt(1)}, t)
+
+	fieldData.Rules = safe.Rules{safe.GreaterThanOrEqual(0.1)}
+
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: 0.09}, {Val: 0}}, t, safe.GreaterThanOrEqualMsg(0.1))
+	testFieldWithOkValues(fieldData, []any{0.1, float32(0.1), json.Number("0.1"), big.NewRat(1, 10)}, t)
+
+	fieldData.Rules = safe.Rules{safe.LessThan(1.5)}
+
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: 1.5}, {Val: 2}, {Val: math.Inf(1)}}, t, safe.LessThanMsg(1.5))
+	testFieldWithOkValues(fieldData, []any{1.49, 1, -100, int8(-1)}, t)
+
+	fieldData.Rules = safe.Rules{safe.LessThanOrEqual(0.3)}
+
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: 0.31}, {Val: 1}}, t, safe.LessThanOrEqualMsg(0.3))
+	testFieldWithOkValues(fieldData, []any{0.3, float32(0.3), json.Number("0.30"), 0}, t)
+}
+
+func TestMultipleOfRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "price",
+		Rules: safe.Rules{safe.MultipleOf(0.05)},
+	}
+
+	invalidValues := []*invalidValue{{Val: 0.01}, {Val: 1.99}, {Val: json.Number("0.051")}, {Val: "0.05"}}
+	okValues := []any{0, 0.05, 0.1, 19.95, 3, json.Number("2.50"), big.NewRat(1, 20)}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.MultipleOfMsg(0.05))
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	fieldData.Rules = safe.Rules{safe.MultipleOf(0)}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: 0}, {Val: 1}}, t, safe.MultipleOfMsg(0))
+}
+
+func TestMaxDecimalPlacesRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "amount",
+		Rules: safe.Rules{safe.MaxDecimalPlaces(2)},
+	}
+
+	invalidValues := []*invalidValue{{Val: 0.001}, {Val: 19.999}, {Val: json.Number("1.005")}, {Val: big.NewRat(1, 3)}}
+	okValues := []any{0, 1, 19.99, 0.1, float32(19.99), json.Number("1.500"), big.NewRat(1, 4), int64(100)}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.MaxDecimalPlacesMsg(2))
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	fieldData.Rules = safe.Rules{safe.MaxDecimalPlaces(1)}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: 0.25}}, t, "Máximo de 1 casa decimal")
+}
+
 func TestOneOfRule(t *testing.T) {
 	fieldData := &safe.Field{
 		Name:  "one of",
@@ -618,3 +936,1073 @@ func TestWithMessage(t *testing.T) {
 
 	testFieldWithInvalidValues(fieldData, invalidValues, t)
 }
+
+func TestInscricaoEstadualRule(t *testing.T) {
+	okValuesByUF := map[string][]string{
+		"AC": {"01.004.823/001-12"},
+		"AL": {"240000048"},
+		"AM": {"04.145.871-0"},
+		"AP": {"030123459"},
+		"BA": {"123456-63", "612345-57", "1000003-06"},
+		"CE": {"06000001-5"},
+		"DF": {"07300001001-09"},
+		"ES": {"999999990"},
+		"GO": {"10.987.654-7"},
+		"MA": {"120000385"},
+		"MG": {"062.307.904/0081"},
+		"MS": {"28.311.594-7"},
+		"MT": {"0013000001-9", "130000019"},
+		"PA": {"15-999999-5"},
+		"PB": {"06000001-5"},
+		"PE": {"0321418-40", "18.1.001.0000004-9"},
+		"PI": {"012345679"},
+		"PR": {"123.45678-50"},
+		"RJ": {"99.999.99-3"},
+		"RN": {"20.040.040-1", "20.0.040.040-0"},
+		"RO": {"0000000062521-3"},
+		"RR": {"24006628-1"},
+		"RS": {"224/3658792"},
+		"SC": {"251.040.852"},
+		"SE": {"27123456-3"},
+		"SP": {"110.042.490.114", "P-01100424.3/002"},
+		"TO": {"29.01.022783-6", "290227836"},
+	}
+
+	if len(okValuesByUF) != 27 {
+		t.Fatalf("Expected samples for 27 states. Got: %d", len(okValuesByUF))
+	}
+
+	invalidValuesByUF := map[string][]*invalidValue{
+		"AL": {{Val: "250000045"}},
+		"SP": {{Val: "P-01100424.4/002"}, {Val: "X-01100424.3/002"}},
+		"TO": {{Val: "29.04.022783-6"}},
+		"XX": {{Val: "062.307.904/0081"}},
+	}
+
+	for uf, invalidValues := range invalidValuesByUF {
+		fieldData := &safe.Field{
+			Name:  "ie",
+			Rules: safe.Rules{safe.InscricaoEstadual(uf)},
+		}
+		testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	}
+
+	for uf, ies := range okValuesByUF {
+		fieldData := &safe.Field{
+			Name:  "ie",
+			Rules: safe.Rules{safe.InscricaoEstadual(uf)},
+		}
+
+		okValues := []any{""}
+		invalidValues := []*invalidValue{{Val: "123"}, {Val: 123}, {Val: "ISENTO"}, {Val: "isento"}}
+		for _, ie := range ies {
+			okValues = append(okValues, ie)
+			if ie[0] == 'P' {
+				// the check digit of rural producers is not the last one
+				continue
+			}
+
+			// changing the last digit breaks the check digit
+			last := ie[len(ie)-1]
+			broken := ie[:len(ie)-1] + string('0'+(last-'0'+1)%10)
+			invalidValues = append(invalidValues, &invalidValue{Val: broken})
+		}
+
+		testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+		testFieldWithOkValues(fieldData, okValues, t)
+	}
+}
+
+func TestInscricaoEstadualOrIsentoRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "ie",
+		Rules: safe.Rules{safe.InscricaoEstadualOrIsento("MG")},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: "062.307.904/0082"},
+		{Val: "ISENTA"},
+		{Val: "ISENTO 1"},
+		{Val: 123},
+	}
+	okValues := []any{"", "ISENTO", "isento", " Isento ", "062.307.904/0081", "0623079040081"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	uf := ""
+	fieldData.Rules = safe.Rules{safe.InscricaoEstadualOrIsentoFrom(&uf)}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "062.307.904/0081"}}, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, []any{"ISENTO"}, t)
+
+	uf = "mg"
+	testFieldWithOkValues(fieldData, []any{"062.307.904/0081", "ISENTO"}, t)
+}
+
+func TestInscricaoEstadualFromRule(t *testing.T) {
+	uf := ""
+	fieldData := &safe.Field{
+		Name:  "ie",
+		Rules: safe.Rules{safe.InscricaoEstadualFrom(&uf)},
+	}
+
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "062.307.904/0081"}}, t, safe.InvalidFormatMsg)
+
+	uf = "mg"
+	testFieldWithOkValues(fieldData, []any{"062.307.904/0081", "0623079040081"}, t)
+
+	uf = "SP"
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "062.307.904/0081"}}, t, safe.InvalidFormatMsg)
+}
+
+func TestInternationalPhoneRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "phone",
+		Rules: safe.Rules{safe.InternationalPhone()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "+55 20 99944-5678"},
+		{Val: "+351 812 345 678"},
+		{Val: "+1 (123) 555-0100"},
+		{Val: "+999 1234 5678"},
+		{Val: "(35) 99944-5678"},
+		{Val: "+55 35 99944-5678 x"},
+		{Val: "+1 1 202 555 0123"},
+		{Val: "+55 0 35 99944 5678"},
+		{Val: "+44 0 7911 123456"},
+		{Val: "+44 (0)20 7946 0018"},
+		{Val: 5535999445678},
+	}
+	okValues := []any{
+		"+55 (35) 99944-5678",
+		"+351 912 345 678",
+		"00351 212 345 678",
+		"+54 9 11 1234-5678",
+		"+1 (415) 555-0100",
+		"+44 20 7946 0018",
+	}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	fieldData.Rules = safe.Rules{safe.InternationalPhone("BR", "PT")}
+
+	invalidValues = []*invalidValue{
+		{Val: "+1 (415) 555-0100"},
+		{Val: "+54 9 11 1234-5678"},
+		{Val: "(20) 5555-0100"},
+	}
+	okValues = []any{
+		"+55 (35) 99944-5678",
+		"(35) 3344-5678",
+		"912 345 678",
+	}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestParsePhoneNumber(t *testing.T) {
+	expectedNumbers := map[string]safe.PhoneNumber{
+		"+55 (35) 99944-5678": {Country: "BR", CallingCode: "55", NationalNumber: "35999445678", Mobile: true},
+		"+351 212 345 678":    {Country: "PT", CallingCode: "351", NationalNumber: "212345678"},
+		"+54 9 11 1234-5678":  {Country: "AR", CallingCode: "54", NationalNumber: "91112345678", Mobile: true},
+		"+1 415 555 0100":     {Country: "US", CallingCode: "1", NationalNumber: "4155550100"},
+		"0044 7911 123456":    {Country: "GB", CallingCode: "44", NationalNumber: "7911123456", Mobile: true},
+		"+598 94 231 234":     {Country: "UY", CallingCode: "598", NationalNumber: "94231234", Mobile: true},
+		"+55 (35) 3344-5678":  {Country: "BR", CallingCode: "55", NationalNumber: "3533445678"},
+		"+52 55 1234 5678":    {Country: "MX", CallingCode: "52", NationalNumber: "5512345678"},
+		"+56 9 6123 4567":     {Country: "CL", CallingCode: "56", NationalNumber: "961234567", Mobile: true},
+		"+57 321 123 4567":    {Country: "CO", CallingCode: "57", NationalNumber: "3211234567", Mobile: true},
+		"+34 612 34 56 78":    {Country: "ES", CallingCode: "34", NationalNumber: "612345678", Mobile: true},
+		"+33 6 12 34 56 78":   {Country: "FR", CallingCode: "33", NationalNumber: "612345678", Mobile: true},
+		"+49 151 23456789":    {Country: "DE", CallingCode: "49", NationalNumber: "15123456789", Mobile: true},
+		"+39 06 1234 5678":    {Country: "IT", CallingCode: "39", NationalNumber: "0612345678"},
+		"+51 912 345 678":     {Country: "PE", CallingCode: "51", NationalNumber: "912345678", Mobile: true},
+	}
+
+	for phone, expected := range expectedNumbers {
+		number, ok := safe.ParsePhoneNumber(phone)
+		if !ok || number != expected {
+			t.Errorf("Expected %q to be parsed as %+v. Got: %+v, %v", phone, expected, number, ok)
+		}
+	}
+
+	for _, phone := range []string{"+1 1 202 555 0123", "+55 0 35 99944 5678", "+44 0 7911 123456"} {
+		if number, ok := safe.ParsePhoneNumber(phone); ok {
+			t.Errorf("Expected %q to be invalid, since the trunk prefix is not dialed after the calling code. Got: %+v", phone, number)
+		}
+	}
+
+	number, ok := safe.ParsePhoneNumber("1 (415) 555-0100", "BR", "US")
+	if !ok || number.Country != "US" || number.E164() != "+14155550100" {
+		t.Errorf("Expected a US number in the national format. Got: %+v, %v", number, ok)
+	}
+}
+
+func TestPixKeyRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "pix_key",
+		Rules: safe.Rules{safe.PixKey()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "393.546.320-09"},
+		{Val: "39354632008"},
+		{Val: "45769852000187"},
+		{Val: "35999445678"},
+		{Val: "+5520999445678"},
+		{Val: "+351912345678"},
+		{Val: "Pix@Example.com"},
+		{Val: "pix@"},
+		{Val: strings.Repeat("a", 66) + "@example.com"},
+		{Val: "123E4567-E89B-12D3-A456-426614174000"},
+		{Val: 39354632009},
+	}
+	okValues := []any{
+		"",
+		"39354632009",
+		"45769852000186",
+		"12ABC34501DE35",
+		"+5535999445678",
+		"pix@example.com",
+		strings.Repeat("a", 65) + "@example.com",
+		"123e4567-e89b-12d3-a456-426614174000",
+	}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestPixKeyOfTypeRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "pix_key",
+		Rules: safe.Rules{safe.PixKeyOfType(safe.PixKeyEmail)},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: "39354632009"},
+		{Val: "+5535999445678"},
+	}
+	okValues := []any{"pix@example.com"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestPixKeyTypeOf(t *testing.T) {
+	expectedTypes := map[string]safe.PixKeyType{
+		"39354632009":                          safe.PixKeyCPF,
+		"45769852000186":                       safe.PixKeyCNPJ,
+		"+5535999445678":                       safe.PixKeyPhone,
+		"pix@example.com":                      safe.PixKeyEmail,
+		"123e4567-e89b-12d3-a456-426614174000": safe.PixKeyEVP,
+		"39354632008":                          "",
+	}
+
+	for key, expected := range expectedTypes {
+		keyType, ok := safe.PixKeyTypeOf(key)
+		if keyType != expected || ok != (expected != "") {
+			t.Errorf("Expected type of %q: %q. Got: %q, %v", key, expected, keyType, ok)
+		}
+	}
+}
+
+func TestBoletoBarcodeRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "barcode",
+		Rules: safe.Rules{safe.BoletoBarcode()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "00193373700000001000500940144816060680935032"},
+		{Val: "00194373700000001000500940144816060680935031"},
+		{Val: "83650000001331201380008128846271108013618155"},
+		{Val: "00190500954014481606906809350314337370000000100"},
+		{Val: "0019337370000000100050094014481606068093503a"},
+	}
+	okValues := []any{
+		"",
+		"00193373700000001000500940144816060680935031",
+		"83640000001331201380008128846271108013618155",
+		"85860000001234500010000000000000020241231125",
+	}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestLinhaDigitavelRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "linha_digitavel",
+		Rules: safe.Rules{safe.LinhaDigitavel()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "00190.50095 40144.816069 06809.350314 4 37370000000100"},
+		{Val: "00190.50096 40144.816069 06809.350314 3 37370000000100"},
+		{Val: "00190.50095 40144.816068 06809.350314 3 37370000000100"},
+		{Val: "00190.50095 40144.816069 06809.350315 3 37370000000100"},
+		{Val: "83640000001-2 33120138000-2 81288462711-6 08013618155-1"},
+		{Val: "00193373700000001000500940144816060680935031"},
+	}
+	okValues := []any{
+		"",
+		"00190.50095 40144.816069 06809.350314 3 37370000000100",
+		"00190500954014481606906809350314337370000000100",
+		"83640000001-1 33120138000-2 81288462711-6 08013618155-1",
+		"858600000012 234500010002 000000000000 202412311258",
+	}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestParseBoleto(t *testing.T) {
+	now := time.Date(2008, time.January, 10, 0, 0, 0, 0, time.UTC)
+	boleto, ok := safe.ParseBoletoAt("00190.50095 40144.816069 06809.350314 3 37370000000100", now)
+
+	expected := safe.Boleto{
+		Kind:     safe.BoletoBancario,
+		Barcode:  "00193373700000001000500940144816060680935031",
+		BankCode: "001",
+		Amount:   100,
+		DueDate:  time.Date(2007, time.December, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if !ok || boleto != expected {
+		t.Errorf("Expected boleto: %+v. Got: %+v, %v", expected, boleto, ok)
+	}
+
+	// factors start over at 1000 on 2025-02-22
+	now = time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)
+	boleto, ok = safe.ParseBoletoAt("00190500954014481606906809350314912340000012345", now)
+	if expectedDueDate := time.Date(2025, time.October, 14, 0, 0, 0, 0, time.UTC); !ok || !boleto.DueDate.Equal(expectedDueDate) || boleto.Amount != 12345 {
+		t.Errorf("Expected due date %s and amount 12345. Got: %+v, %v", expectedDueDate, boleto, ok)
+	}
+
+	boleto, ok = safe.ParseBoleto("83640000001-1 33120138000-2 81288462711-6 08013618155-1")
+	if !ok || boleto.Kind != safe.BoletoArrecadacao || boleto.Amount != 13312 || !boleto.DueDate.IsZero() {
+		t.Errorf("Expected a convênio slip with amount 13312. Got: %+v, %v", boleto, ok)
+	}
+}
+
+func TestChaveAcessoRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "chave_acesso",
+		Rules: safe.Rules{safe.ChaveAcesso()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "35241045769852000186550010000001231123456784"},
+		{Val: "3524104576985200018655001000000123112345678"},
+		{Val: "10241045769852000186550010000001231123456788"},
+		{Val: "35241345769852000186550010000001231123456784"},
+		{Val: "35241045769852000186560010000001231123456784"},
+		{Val: "35260812abc34501de35570010000000011000000015"},
+	}
+	okValues := []any{
+		"",
+		"35241045769852000186550010000001231123456783",
+		"3524 1045 7698 5200 0186 5500 1000 0001 2311 2345 6783",
+		"31260211789602000196650020000045671876543210",
+		"35260812ABC34501DE35570010000000011000000015",
+	}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	fieldData.Rules = safe.Rules{safe.ChaveAcesso("55", "65")}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "35260812ABC34501DE35570010000000011000000015"}}, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, []any{"31260211789602000196650020000045671876543210"}, t)
+}
+
+func TestParseChaveAcesso(t *testing.T) {
+	chave, ok := safe.ParseChaveAcesso("35241045769852000186550010000001231123456783")
+
+	expected := safe.ChaveAcessoComponents{
+		UF:           "SP",
+		UFCode:       "35",
+		Year:         2024,
+		Month:        time.October,
+		Emitter:      "45769852000186",
+		Model:        "55",
+		Series:       "001",
+		Number:       "000000123",
+		EmissionType: "1",
+		Code:         "12345678",
+		CheckDigit:   "3",
+	}
+	if !ok || chave != expected {
+		t.Errorf("Expected chave de acesso: %+v. Got: %+v, %v", expected, chave, ok)
+	}
+
+	if chave.ModelName() != "NF-e" || !safe.IsValidCnpj(chave.Emitter) {
+		t.Errorf("Expected a NF-e with a valid emitter. Got: %s, %s", chave.ModelName(), chave.Emitter)
+	}
+}
+
+func TestPlateRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "plate",
+		Rules: safe.Rules{safe.Plate()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "AB-1234"},
+		{Val: "ABC-12345"},
+		{Val: "ABC1DD3"},
+		{Val: "ABC 1234"},
+		{Val: 1234},
+	}
+	okValues := []any{"", "ABC-1234", "ABC1234", "ABC1D23", "abc1d23"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	fieldData.Rules = safe.Rules{safe.MercosulPlate()}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "ABC-1234"}}, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, []any{"ABC1D23"}, t)
+}
+
+func TestPlateConversion(t *testing.T) {
+	expectedMercosul := map[string]string{
+		"ABC-1234": "ABC1C34",
+		"abc1034":  "ABC1A34",
+		"XYZ-9999": "XYZ9J99",
+		"ABC1D23":  "ABC1D23",
+		"AB-1234":  "",
+	}
+	for plate, expected := range expectedMercosul {
+		converted, ok := safe.PlateToMercosul(plate)
+		if converted != expected || ok != (expected != "") {
+			t.Errorf("Expected %q in the Mercosul format: %q. Got: %q, %v", plate, expected, converted, ok)
+		}
+	}
+
+	expectedOld := map[string]string{
+		"ABC1C34":  "ABC-1234",
+		"XYZ9J99":  "XYZ-9999",
+		"ABC1234":  "ABC-1234",
+		"ABC1K34":  "",
+		"ABC-1D23": "",
+	}
+	for plate, expected := range expectedOld {
+		converted, ok := safe.PlateToOld(plate)
+		if converted != expected || ok != (expected != "") {
+			t.Errorf("Expected %q in the old format: %q. Got: %q, %v", plate, expected, converted, ok)
+		}
+	}
+}
+
+func TestRenavamRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "renavam",
+		Rules: safe.Rules{safe.Renavam()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "00639884963"},
+		{Val: "639884962"},
+		{Val: "0063988496a"},
+		{Val: 639884962},
+	}
+	okValues := []any{"", "00639884962"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestVINRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "vin",
+		Rules: safe.Rules{safe.VIN()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "1M8GDM9AXKP04278"},
+		{Val: "1M8GDM9AXKP04278I"},
+		{Val: "1HGCM82633A00435O"},
+		{Val: "9BWZZZ377VT00425Q"},
+		{Val: 12345678901234567},
+	}
+	okValues := []any{"", "1M8GDM9AXKP042788", "1M8GDM9AYKP042788", "1m8gdm9axkp042788", "9BWZZZ377VT004251"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestVINWithCheckDigitRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "vin",
+		Rules: safe.Rules{safe.VINWithCheckDigit()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "1M8GDM9AYKP042788"},
+		{Val: "9BWZZZ377VT004251"},
+		{Val: "1M8GDM9AXKP04278"},
+		{Val: "1HGCM82633A00435O"},
+	}
+	okValues := []any{"", "1M8GDM9AXKP042788", "1m8gdm9axkp042788", "11111111111111111"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestPisRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "pis",
+		Rules: safe.Rules{safe.Pis()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "12056412546"},
+		{Val: "120.56412.54-6"},
+		{Val: "1205641254"},
+		{Val: "11111111111"},
+		{Val: "120,56412,54-5"},
+	}
+	okValues := []any{"", "12056412545", "120.56412.54-5", "170.33799.25-8", "12345678900"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestTituloEleitorRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "titulo_eleitor",
+		Rules: safe.Rules{safe.TituloEleitor()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "004356870907"},
+		{Val: "004356870916"},
+		{Val: "004356872906"},
+		{Val: "00435687090"},
+		{Val: "0043-5687-0906"},
+	}
+	okValues := []any{"", "004356870906", "0043 5687 0906", "102312340264", "123456780191"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	expectedUFs := map[string]string{
+		"0043 5687 0906": "SC",
+		"102312340264":   "MG",
+		"123456780191":   "SP",
+		"004356870907":   "",
+	}
+	for titulo, expected := range expectedUFs {
+		uf, ok := safe.TituloEleitorUF(titulo)
+		if uf != expected || ok != (expected != "") {
+			t.Errorf("Expected uf of %q: %q. Got: %q, %v", titulo, expected, uf, ok)
+		}
+	}
+}
+
+func TestCnhRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "cnh",
+		Rules: safe.Rules{safe.Cnh()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "12345678901"},
+		{Val: "02738495007"},
+		{Val: "0273849500"},
+		{Val: "00000000000"},
+		{Val: "027.384.950-06"},
+	}
+	okValues := []any{"", "12345678900", "02738495006", "04512388705"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestCnsRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "cns",
+		Rules: safe.Rules{safe.Cns()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "123456789010001"},
+		{Val: "100000000060008"},
+		{Val: "270000000000005"},
+		{Val: "898765432101234"},
+		{Val: "398765432101233"},
+		{Val: "89876543210123"},
+	}
+	okValues := []any{"", "123456789010000", "270000000000004", "100000000060018", "198 7654 3210 0003", "898 7654 3210 1233", "700000000000005"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestBankCodeRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "bank",
+		Rules: safe.Rules{safe.BankCode()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "999"},
+		{Val: "1"},
+		{Val: "12345678"},
+		{Val: 1},
+	}
+	okValues := []any{"", "001", "341", "260", "00000000", "18236120"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestBankByCode(t *testing.T) {
+	bank, ok := safe.BankByCode("60701190")
+	if !ok || bank.Code != "341" || bank.Name != "Itaú Unibanco" {
+		t.Errorf("expected to find Itaú by its ispb, got %v (%v)", bank, ok)
+	}
+
+	if _, ok := safe.BankByCode("999"); ok {
+		t.Errorf("expected bank 999 to be unknown")
+	}
+}
+
+func TestBankAgencyRule(t *testing.T) {
+	okValuesByBank := map[string][]any{
+		"001": {"1584-9", "15849"},
+		"237": {"1234-3"},
+		"341": {"2545", "545"},
+		"260": {"0001"},
+		"756": {"3069", "3069-1"},
+		// unknown by safe.BankByCode, like Ailos, get the generic format
+		"085": {"0101", "0101-1"},
+		// by the ispb
+		"00000000": {"1584-9"},
+		"99999999": {"12345"},
+	}
+	invalidValuesByBank := map[string][]*invalidValue{
+		"001": {{Val: "1584-8"}, {Val: "1584"}, {Val: "a584-9"}, {Val: "11584-9"}},
+		"237": {{Val: "1234-0"}},
+		"341": {{Val: "25450"}, {Val: "2545-1"}},
+		"260": {{Val: "0002"}},
+		"756": {{Val: "3069123"}},
+		"085": {{Val: "1234567"}},
+		"99":  {{Val: "0001"}},
+		"abc": {{Val: "0001"}},
+	}
+
+	for bankCode, okValues := range okValuesByBank {
+		fieldData := &safe.Field{
+			Name:  "agency",
+			Rules: safe.Rules{safe.BankAgency(bankCode)},
+		}
+		testFieldWithOkValues(fieldData, append(okValues, ""), t)
+	}
+
+	for bankCode, invalidValues := range invalidValuesByBank {
+		fieldData := &safe.Field{
+			Name:  "agency",
+			Rules: safe.Rules{safe.BankAgency(bankCode)},
+		}
+		testFieldWithInvalidValues(fieldData, append(invalidValues, &invalidValue{Val: 1}), t, safe.InvalidFormatMsg)
+	}
+}
+
+func TestBankAccountRule(t *testing.T) {
+	type agency struct{ bankCode, agency string }
+
+	okValuesByAgency := map[agency][]any{
+		{"001", "1584-9"}:      {"00210169-6", "210169-6", "002101696"},
+		{"237", "1234-3"}:      {"0238069-2", "238069-2"},
+		{"341", "2545"}:        {"02366-1"},
+		{"033", "1234"}:        {"01017417-3"},
+		{"104", "2004"}:        {"001-00000448-6", "00100000448-6"},
+		{"041", "1234"}:        {"358507671-8"},
+		{"260", "0001"}:        {"12345678-9"},
+		{"756", "3069"}:        {"12345-6", "12345-X"},
+		{"60746948", "1234-3"}: {"0238069-2"},
+		{"085", "0101"}:        {"12345-6"},
+	}
+	invalidValuesByAgency := map[agency][]*invalidValue{
+		{"001", "1584-9"}: {{Val: "00210169-5"}, {Val: "100210169-6"}, {Val: "0021016a-6"}},
+		// the agency is not valid
+		{"001", "1584-8"}: {{Val: "00210169-6"}},
+		{"237", "1234-3"}: {{Val: "0238069-3"}},
+		// the check digit depends on the agency
+		{"341", "2546"}:  {{Val: "02366-1"}},
+		{"033", "1234"}:  {{Val: "01017417-9"}},
+		{"104", "2004"}:  {{Val: "001-00000448-7"}},
+		{"041", "1234"}:  {{Val: "358507671-7"}},
+		{"260", "0001"}:  {{Val: "12345678-X"}, {Val: "12345678901-2"}},
+		{"756", "3069"}:  {{Val: "12345-Y"}},
+		{"085", "0101"}:  {{Val: "12345-Y"}},
+		{"9999", "0001"}: {{Val: "12345-6"}},
+	}
+
+	for a, okValues := range okValuesByAgency {
+		fieldData := &safe.Field{
+			Name:  "account",
+			Rules: safe.Rules{safe.BankAccount(a.bankCode, a.agency)},
+		}
+		testFieldWithOkValues(fieldData, append(okValues, ""), t)
+	}
+
+	for a, invalidValues := range invalidValuesByAgency {
+		fieldData := &safe.Field{
+			Name:  "account",
+			Rules: safe.Rules{safe.BankAccount(a.bankCode, a.agency)},
+		}
+		testFieldWithInvalidValues(fieldData, append(invalidValues, &invalidValue{Val: 1}), t, safe.InvalidFormatMsg)
+	}
+}
+
+func TestCardNumberRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "card_number",
+		Rules: safe.Rules{safe.CardNumber()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "4111111111111112"},
+		{Val: "41111111111"},
+		{Val: "4111 1111 1111 111a"},
+		{Val: 4111111111111111},
+	}
+	okValues := []any{"", "4111111111111111", "4111 1111 1111 1111", "5555-5555-5555-4444", "378282246310005"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	fieldData.Rules = safe.Rules{safe.CardNumber(safe.CardVisa, safe.CardElo)}
+
+	invalidValues = []*invalidValue{{Val: "5555555555554444"}, {Val: "378282246310005"}}
+	okValues = []any{"", "4111111111111111", "6362970000457013"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestCardBrandOf(t *testing.T) {
+	expectedBrands := map[string]safe.CardBrand{
+		"4111111111111111":    safe.CardVisa,
+		"4222222222222":       safe.CardVisa,
+		"5555555555554444":    safe.CardMastercard,
+		"2221000000000009":    safe.CardMastercard,
+		"378282246310005":     safe.CardAmex,
+		"6362970000457013":    safe.CardElo,
+		"6062825624254001":    safe.CardHipercard,
+		"30569309025904":      safe.CardDiners,
+		"6011111111111117":    safe.CardDiscover,
+		"3530111333300000":    safe.CardJCB,
+		"4111 1111 1111 1111": safe.CardVisa,
+		// valid, but of an unknown brand
+		"9999999999999995": "",
+		"4111111111111112": "",
+	}
+
+	for number, expected := range expectedBrands {
+		brand, ok := safe.CardBrandOf(number)
+		if brand != expected || ok != (expected != "") {
+			t.Errorf("Expected brand %q for %s. Got: %q, %v", expected, number, brand, ok)
+		}
+	}
+}
+
+func TestCardExpiryRule(t *testing.T) {
+	now := func() time.Time { return time.Date(2026, time.March, 31, 23, 0, 0, 0, time.UTC) }
+
+	fieldData := &safe.Field{
+		Name:  "card_expiry",
+		Rules: safe.Rules{safe.CardExpiryAt(now)},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "13/26"},
+		{Val: "3/26"},
+		{Val: "03-26"},
+		{Val: "03/026"},
+		{Val: 326},
+	}
+	okValues := []any{"", "03/26", "04/26", "12/2030", "01 / 27"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	invalidValues = []*invalidValue{{Val: "02/26"}, {Val: "12/2025"}}
+	testFieldWithInvalidValues(fieldData, invalidValues, t, "Cartão vencido")
+
+	fieldData.Rules = safe.Rules{safe.CardExpiry()}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "01/2000"}}, t, "Cartão vencido")
+	testFieldWithOkValues(fieldData, []any{"12/2099"}, t)
+}
+
+func TestCVVRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "cvv",
+		Rules: safe.Rules{safe.CVV(safe.CardVisa)},
+	}
+
+	invalidValues := []*invalidValue{{Val: " "}, {Val: "12"}, {Val: "1234"}, {Val: "12a"}, {Val: 123}}
+	okValues := []any{"", "123", "000"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	fieldData.Rules = safe.Rules{safe.CVV(safe.CardAmex)}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "123"}}, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, []any{"1234"}, t)
+
+	fieldData.Rules = safe.Rules{safe.CVV("")}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "12345"}}, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, []any{"123", "1234"}, t)
+}
+
+func TestIBANRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "iban",
+		Rules: safe.Rules{safe.IBAN()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "DE89370400440532013001"},
+		{Val: "DE8937040044053201300"},
+		{Val: "DE89 3704 0044 0532 0130 00 0"},
+		{Val: "GB82 1234 1234 5698 7654 32"},
+		{Val: "US12 3456 7890 1234"},
+		{Val: "DEXX370400440532013000"},
+		{Val: "VA59001123000012345679"},
+		{Val: "LY8300204800002010012036"},
+		{Val: "SC18SSCB1101000000000000149712D"},
+		{Val: 8937040044},
+	}
+	okValues := []any{
+		"",
+		"DE89370400440532013000",
+		"DE89 3704 0044 0532 0130 00",
+		"de89 3704 0044 0532 0130 00",
+		"GB82 WEST 1234 5698 7654 32",
+		"FR14 2004 1010 0505 0001 3M02 606",
+		"NL91 ABNA 0417 1643 00",
+		"PT50 0002 0123 1234 5678 9015 4",
+		"BR18 0036 0305 0000 1000 9795 493C 1",
+		"VA59001123000012345678",
+		"LY83002048000020100120361",
+		"BY13NBRB3600900000002Z00AB00",
+		"IQ98NBIQ850123456789012",
+		"LC55HEMM000100010012001200023015",
+		"SC18SSCB11010000000000001497USD",
+		"ST68000100010051845310112",
+		"SV62CENR00000000000000700025",
+		"TL380080012345678910157",
+		"SD2129010501234001",
+		"BI4210000100010000332045181",
+		"DJ2100010000000154000100186",
+		"FK88SC123456789012",
+		"MN121234123456789123",
+		"NI45BAPR00000013000003558124",
+		"OM810180000001299123456",
+		"RU0304452522540817810538091310419",
+		"SO211000001001000100141",
+		"YE15CBYE0001018861234567891234",
+		"HN88CABF00000000000250005469",
+		"MR1300020001010000123456753",
+	}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	fieldData.Rules = safe.Rules{safe.IBAN("DE", "pt")}
+
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "NL91ABNA0417164300"}}, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, []any{"DE89370400440532013000", "PT50000201231234567890154"}, t)
+}
+
+func TestFormatIBAN(t *testing.T) {
+	expectedIBANs := map[string]string{
+		"DE89370400440532013000":      "DE89 3704 0044 0532 0130 00",
+		"de89 3704 0044 0532 0130 00": "DE89 3704 0044 0532 0130 00",
+		"NL91-ABNA-0417-1643-00":      "NL91 ABNA 0417 1643 00",
+		"PT50000201231234567890154":   "PT50 0002 0123 1234 5678 9015 4",
+		"":                            "",
+	}
+
+	for iban, expected := range expectedIBANs {
+		if formatted := safe.FormatIBAN(iban); formatted != expected {
+			t.Errorf("Expected %q for %q. Got: %q", expected, iban, formatted)
+		}
+	}
+
+	if normalized := safe.NormalizeIBAN("de89 3704 0044 0532 0130 00"); normalized != "DE89370400440532013000" {
+		t.Errorf("Expected DE89370400440532013000. Got: %s", normalized)
+	}
+}
+
+func TestBICRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "bic",
+		Rules: safe.Rules{safe.BIC()},
+	}
+
+	invalidValues := []*invalidValue{
+		{Val: " "},
+		{Val: "DEUTDEF"},
+		{Val: "DEUTDEFF50"},
+		{Val: "DEUTXXFF"},
+		{Val: "DEU1DEFF"},
+		{Val: "DEUT DE FF"},
+		{Val: 12345678},
+	}
+	okValues := []any{"", "DEUTDEFF", "DEUTDEFF500", "deutdeff", "NEDSZAJJXXX", "BOFAUS3N", "BRASBRRJBHE"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestParseDecimal(t *testing.T) {
+	expectedDecimals := []struct {
+		str, locale, expected string
+	}{
+		{"1.234,56", "pt-BR", "30864/25"},
+		{"1234,56", "", "30864/25"},
+		{"-0,5", "pt", "-1/2"},
+		{"1.234.567", "es", "1234567/1"},
+		{"1,234.56", "en", "30864/25"},
+		{"1,234.56", "en-US", "30864/25"},
+		{"1234.56", "en", "30864/25"},
+		{" 42 ", "en", "42/1"},
+		{"1.234,56", "en", ""},
+		{"1,234.56", "pt-BR", ""},
+		{"1.23,4", "pt-BR", ""},
+		{"1.5", "pt-BR", ""},
+		{"R$ 1,00", "pt-BR", ""},
+		{"1,00", "fr", ""},
+		{"", "pt-BR", ""},
+	}
+
+	for _, d := range expectedDecimals {
+		n, ok := safe.ParseDecimal(d.str, d.locale)
+		if d.expected == "" {
+			if ok {
+				t.Errorf("Expected %q not to be a valid decimal in %q. Got: %s", d.str, d.locale, n)
+			}
+			continue
+		}
+		if !ok || n.String() != d.expected {
+			t.Errorf("Expected %s for %q in %q. Got: %v, %v", d.expected, d.str, d.locale, n, ok)
+		}
+	}
+}
+
+func TestDecimalStringRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "price",
+		Rules: safe.Rules{safe.DecimalString("pt-BR", safe.GreaterThan(0), safe.LessThanOrEqual(10000), safe.CurrencyAmount("BRL"))},
+	}
+
+	invalidValues := []*invalidValue{{Val: "abc"}, {Val: "1,234.56"}, {Val: " "}, {Val: 1234.56}}
+	okValues := []any{"", "1.234,56", "0,01", "10.000", "10000,00", "99"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "0,00"}, {Val: "-1"}}, t, safe.GreaterThanMsg(0))
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "10.000,01"}}, t, safe.LessThanOrEqualMsg(10000))
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "1,234"}}, t, safe.MaxDecimalPlacesMsg(2))
+
+	fieldData.Rules = safe.Rules{safe.DecimalString("en", safe.Min(5).WithMessage("{value} is less than {min}"))}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: "4.99"}}, t, "4.99 is less than 5")
+	testFieldWithOkValues(fieldData, []any{"1,000", "5"}, t)
+}
+
+func TestDecimalStringRuleUsesTheLocaleOfTheValidation(t *testing.T) {
+	fields := safe.Fields{
+		{
+			Name:  "amount",
+			Value: "1,234.5",
+			Rules: safe.Rules{safe.DecimalString("", safe.CurrencyAmount("USD"))},
+		},
+	}
+
+	if msgs, ok := safe.ValidateWithLocale(fields, "en"); !ok {
+		t.Errorf("Expected 1,234.5 to be valid in en. Got: %v", msgs)
+	}
+
+	msgs, ok := safe.ValidateWithLocale(fields, "pt-BR")
+	if ok || msgs["amount"] != safe.InvalidFormatMsg {
+		t.Errorf("Expected 1,234.5 not to be valid in pt-BR. Got: %v", msgs)
+	}
+
+	var validationErrs safe.ValidationErrors
+	fields[0].Value = "1,234.567"
+	if err := safe.CheckWithLocale(fields, "en"); !errors.As(err, &validationErrs) || validationErrs[0].Code != safe.MaxDecimalPlacesCode || validationErrs[0].Params["currency"] != "USD" {
+		t.Errorf("Expected a max_decimal_places error for USD. Got: %v", err)
+	}
+}
+
+func TestCurrencyAmountRule(t *testing.T) {
+	expectedPlaces := map[string]int{"BRL": 2, "JPY": 0, "BHD": 3, "CLF": 4}
+
+	for currency, places := range expectedPlaces {
+		fieldData := &safe.Field{
+			Name:  "amount",
+			Rules: safe.Rules{safe.CurrencyAmount(currency)},
+		}
+
+		tooPrecise := json.Number("1." + strings.Repeat("1", places+1))
+		okValue := json.Number("1" + strings.Repeat("0", places))
+
+		testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: tooPrecise}}, t, safe.MaxDecimalPlacesMsg(places))
+		testFieldWithOkValues(fieldData, []any{okValue, 100}, t)
+	}
+
+	fieldData := &safe.Field{
+		Name:  "amount",
+		Rules: safe.Rules{safe.CurrencyAmount("XXX")},
+	}
+	testFieldWithInvalidValues(fieldData, []*invalidValue{{Val: 1}}, t, safe.InvalidValueMsg)
+}
+
+func TestCurrencyCodeRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "currency",
+		Rules: safe.Rules{safe.CurrencyCode()},
+	}
+
+	invalidValues := []*invalidValue{{Val: " "}, {Val: "brl"}, {Val: "XXX"}, {Val: "BR"}, {Val: "REAL"}, {Val: 986}}
+	okValues := []any{"", "BRL", "USD", "EUR", "JPY", "BHD"}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidFormatMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
+
+func TestEachRule(t *testing.T) {
+	fieldData := &safe.Field{
+		Name:  "emails",
+		Rules: safe.Rules{safe.Each(safe.Email())},
+	}
+
+	invalidValues := []*invalidValue{{Val: "a@b.com"}, {Val: 1}, {Val: map[string]string{"a": "a@b.com"}}}
+	okValues := []any{nil, []string{}, []string{"a@b.com"}, [2]string{"a@b.com", "c@d.com"}, []any{"a@b.com"}}
+
+	testFieldWithInvalidValues(fieldData, invalidValues, t, safe.InvalidValueMsg)
+	testFieldWithOkValues(fieldData, okValues, t)
+}
@@ -0,0 +1,206 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/cayo-rodrigues/safe"
+)
+
+func TestValidateStructSuccess(t *testing.T) {
+	user := newTaggedUser()
+
+	errs, ok := safe.ValidateStruct(user)
+
+	if !ok || errs != nil {
+		t.Errorf("User should be valid and have no error messages.\nValid: %v.\nError messages: %s.", ok, errs)
+	}
+}
+
+func TestValidateStructFailure(t *testing.T) {
+	user := newTaggedUser()
+	nickname := "pepsiman"
+
+	user.Name = "pe"
+	user.Email = "pepsiman"
+	user.Age = 17
+	user.Job = "pepsiman"
+	user.Level = 13
+	user.Nickname = &nickname
+	user.Code = "1"
+	user.taggedAddress = nil
+
+	errs, ok := safe.ValidateStruct(*user)
+
+	if ok || errs == nil {
+		t.Fatalf("User should not be valid and should have error messages.\nValid: %v.\nError Messages: %s.", ok, errs)
+	}
+
+	expectedErrs := safe.ErrorMessages{
+		"name":     safe.MinCharsMsg(3),
+		"email":    safe.InvalidFormatMsg,
+		"age":      safe.MinValueMsg(18),
+		"Job":      safe.UnacceptableValueMsg,
+		"level":    safe.UnacceptableValueMsg,
+		"nickname": safe.MaxCharsMsg(4),
+		"code":     safe.InvalidFormatMsg,
+		"street":   safe.MandatoryFieldMsg,
+	}
+
+	for name, expectedMsg := range expectedErrs {
+		if msg := errs[name]; msg != expectedMsg {
+			t.Errorf("Expected %s error message: \"%s\". Got: \"%s\"", name, expectedMsg, msg)
+		}
+	}
+
+	if len(errs) != len(expectedErrs) {
+		t.Errorf("Expected %d error messages. Got: %s", len(expectedErrs), errs)
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	rules, err := safe.ParseTag("required, max=128,oneof=a|b,match=^\\d{2\\,4}$")
+	if err != nil {
+		t.Fatalf("tag should be valid. Got: %v", err)
+	}
+
+	expected := []safe.TagRule{
+		{Name: "required"},
+		{Name: "max", Param: "128"},
+		{Name: "oneof", Param: "a|b"},
+		{Name: "match", Param: `^\d{2,4}$`},
+	}
+
+	if len(rules) != len(expected) {
+		t.Fatalf("Expected rules %v. Got: %v", expected, rules)
+	}
+	for i := range expected {
+		if rules[i] != expected[i] {
+			t.Errorf("Expected rule %v. Got: %v", expected[i], rules[i])
+		}
+	}
+
+	invalidTags := []string{"pepsiman", "required=1", "max", "max=a", "oneof=", "match=("}
+	for _, tag := range invalidTags {
+		if _, err := safe.ParseTag(tag); err == nil {
+			t.Errorf("tag %q should not be valid", tag)
+		}
+	}
+}
+
+func TestValidateStructPanics(t *testing.T) {
+	type badlyTagged struct {
+		Name string `safe:"pepsiman"`
+	}
+
+	for _, v := range []any{"not a struct", badlyTagged{}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ValidateStruct should panic for %#v", v)
+				}
+			}()
+			safe.ValidateStruct(v)
+		}()
+	}
+}
+
+func TestGeneratedSafeFields(t *testing.T) {
+	user := newTaggedUser()
+
+	errs, ok := safe.Validate(user.SafeFields())
+
+	if !ok || errs != nil {
+		t.Errorf("User should be valid and have no error messages.\nValid: %v.\nError messages: %s.", ok, errs)
+	}
+
+	nickname := "pepsiman"
+	user.Name = "pe"
+	user.Job = "pepsiman"
+	user.Level = 13
+	user.Nickname = &nickname
+	user.Code = "1"
+	user.taggedAddress = nil
+
+	expectedErrs, _ := safe.ValidateStruct(user)
+	errs, ok = safe.Validate(user.SafeFields())
+
+	if ok || len(errs) != len(expectedErrs) {
+		t.Fatalf("Generated fields should behave like ValidateStruct.\nExpected: %s.\nGot: %s.", expectedErrs, errs)
+	}
+
+	for name, expectedMsg := range expectedErrs {
+		if msg := errs[name]; msg != expectedMsg {
+			t.Errorf("Expected %s error message: \"%s\". Got: \"%s\"", name, expectedMsg, msg)
+		}
+	}
+}
+
+func TestValidateStructNested(t *testing.T) {
+	order := newTaggedOrder()
+
+	errs, ok := safe.ValidateStruct(order)
+
+	if !ok || errs != nil {
+		t.Errorf("Order should be valid and have no error messages.\nValid: %v.\nError messages: %s.", ok, errs)
+	}
+
+	order.Customer.Email = "pepsiman"
+	order.Billing.Cep = "123"
+	order.Items[1].Sku = ""
+	order.Items[0] = nil
+	order.Items = append(order.Items, &taggedItem{Sku: "PEPSIMAAAAAN", Quantity: 0})
+
+	expectedErrs := safe.ErrorMessages{
+		"customer.email":    safe.InvalidFormatMsg,
+		"billing.cep":       safe.InvalidFormatMsg,
+		"items[1].sku":      safe.MandatoryFieldMsg,
+		"items[2].sku":      safe.MaxCharsMsg(8),
+		"items[2].quantity": safe.MinValueMsg(1),
+	}
+
+	for _, fields := range []safe.Fields{safe.StructFields(order), order.SafeFields()} {
+		errs, ok := safe.Validate(fields)
+
+		if ok || len(errs) != len(expectedErrs) {
+			t.Fatalf("Expected error messages %s. Got: %s", expectedErrs, errs)
+		}
+		for name, expectedMsg := range expectedErrs {
+			if msg := errs[name]; msg != expectedMsg {
+				t.Errorf("Expected %s error message: \"%s\". Got: \"%s\"", name, expectedMsg, msg)
+			}
+		}
+	}
+
+	order.Customer = nil
+	order.Items = nil
+
+	for _, fields := range []safe.Fields{safe.StructFields(order), order.SafeFields()} {
+		errs, _ := safe.Validate(fields)
+
+		if errs["customer"] != safe.MandatoryFieldMsg || errs["items"] != safe.MandatoryFieldMsg {
+			t.Errorf("Expected customer and items to be required. Got: %s", errs)
+		}
+	}
+}
+
+func TestValidateStructSkipsJSONIgnoredFields(t *testing.T) {
+	order := newTaggedOrder()
+	order.Pickup = &taggedAddress{Cep: "123"}
+
+	for _, fields := range []safe.Fields{safe.StructFields(order), order.SafeFields()} {
+		errs, ok := safe.Validate(fields)
+
+		if !ok || errs != nil {
+			t.Errorf("Fields tagged with `json:\"-\"` should not be validated.\nValid: %v.\nError messages: %s.", ok, errs)
+		}
+	}
+
+	type secret struct {
+		Token string `json:"-" safe:"required"`
+	}
+
+	errs, _ := safe.ValidateStruct(secret{})
+	if errs["Token"] != safe.MandatoryFieldMsg {
+		t.Errorf("Fields tagged with `json:\"-\"` and `safe` should still be validated. Got: %s", errs)
+	}
+}
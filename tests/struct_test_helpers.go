@@ -0,0 +1,66 @@
+package tests
+
+//go:generate go run ../cmd/safegen -type taggedUser,taggedAddress,taggedOrder,taggedItem
+
+type taggedAddress struct {
+	Street string `json:"street" safe:"required,max=128"`
+	Cep    string `json:"cep" safe:"cep"`
+}
+
+type taggedUser struct {
+	ID       string  `json:"id" safe:"required,uuid"`
+	Name     string  `json:"name" label:"Nome" safe:"required,min=3,max=128"`
+	Email    string  `json:"email,omitempty" safe:"email"`
+	Password string  `json:"password" safe:"required,strongpassword"`
+	Age      int     `json:"age" safe:"min=18,max=60"`
+	Job      string  `safe:"oneof=software developer|designer|po"`
+	Level    int     `json:"level" safe:"notoneof=0|13"`
+	Nickname *string `json:"nickname" safe:"max=4"`
+	Code     string  `json:"code" safe:"match=^\\d{2\\,4}$"`
+	Ignored  string  `json:"ignored"`
+	*taggedAddress
+}
+
+func newTaggedUser() *taggedUser {
+	return &taggedUser{
+		ID:       "f51abc35-4aa1-439b-a985-6d56439901d9",
+		Name:     "some random name rodriguez",
+		Email:    "user@user.com",
+		Password: "^123!q@w#e4R5T6Y$",
+		Age:      25,
+		Job:      "designer",
+		Level:    3,
+		Code:     "123",
+		taggedAddress: &taggedAddress{
+			Street: "Praça Coronel Ernesto Muniz Barreto",
+			Cep:    "49750-970",
+		},
+	}
+}
+
+type taggedItem struct {
+	Sku      string `json:"sku" safe:"required,max=8"`
+	Quantity int    `json:"quantity" safe:"min=1"`
+}
+
+type taggedOrder struct {
+	Customer *taggedUser    `json:"customer" safe:"required"`
+	Shipping *taggedAddress `json:"shipping"`
+	Billing  taggedAddress  `json:"billing"`
+	Items    []*taggedItem  `json:"items" safe:"required"`
+	Pickup   *taggedAddress `json:"-"`
+}
+
+func newTaggedOrder() *taggedOrder {
+	return &taggedOrder{
+		Customer: newTaggedUser(),
+		Billing: taggedAddress{
+			Street: "Praça Coronel Ernesto Muniz Barreto",
+			Cep:    "49750-970",
+		},
+		Items: []*taggedItem{
+			{Sku: "PEPSI", Quantity: 1},
+			{Sku: "COLA", Quantity: 2},
+		},
+	}
+}
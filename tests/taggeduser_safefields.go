@@ -0,0 +1,156 @@
+// Code generated by safegen; DO NOT EDIT.
+
+package tests
+
+import (
+	"regexp"
+
+	"github.com/cayo-rodrigues/safe"
+)
+
+var safegenRegex0 = regexp.MustCompile(`^\d{2,4}$`)
+
+func (t *taggedUser) SafeFields() safe.Fields {
+	if t == nil {
+		return nil
+	}
+	embedded0 := t.taggedAddress
+	if embedded0 == nil {
+		embedded0 = &taggedAddress{}
+	}
+	return safe.Fields{
+		{
+			Name:  "id",
+			Value: t.ID,
+			Rules: safe.Rules{safe.Required(), safe.UUIDstr()},
+		},
+		{
+			Name:  "name",
+			Label: "Nome",
+			Value: t.Name,
+			Rules: safe.Rules{safe.Required(), safe.Min(3), safe.Max(128)},
+		},
+		{
+			Name:  "email",
+			Value: t.Email,
+			Rules: safe.Rules{safe.Email()},
+		},
+		{
+			Name:  "password",
+			Value: t.Password,
+			Rules: safe.Rules{safe.Required(), safe.StrongPassword()},
+		},
+		{
+			Name:  "age",
+			Value: t.Age,
+			Rules: safe.Rules{safe.Min(18), safe.Max(60)},
+		},
+		{
+			Name:  "Job",
+			Value: t.Job,
+			Rules: safe.Rules{safe.OneOf([]string{"software developer", "designer", "po"})},
+		},
+		{
+			Name:  "level",
+			Value: t.Level,
+			Rules: safe.Rules{safe.NotOneOf([]int{0, 13})},
+		},
+		{
+			Name: "nickname",
+			Value: func() (safegenValue string) {
+				if t.Nickname != nil {
+					safegenValue = *t.Nickname
+				}
+				return safegenValue
+			}(),
+			Rules: safe.Rules{safe.Max(4)},
+		},
+		{
+			Name:  "code",
+			Value: t.Code,
+			Rules: safe.Rules{safe.Match(safegenRegex0)},
+		},
+		{
+			Name:  "street",
+			Value: embedded0.Street,
+			Rules: safe.Rules{safe.Required(), safe.Max(128)},
+		},
+		{
+			Name:  "cep",
+			Value: embedded0.Cep,
+			Rules: safe.Rules{safe.CEP()},
+		},
+	}
+}
+
+func (t *taggedAddress) SafeFields() safe.Fields {
+	if t == nil {
+		return nil
+	}
+	return safe.Fields{
+		{
+			Name:  "street",
+			Value: t.Street,
+			Rules: safe.Rules{safe.Required(), safe.Max(128)},
+		},
+		{
+			Name:  "cep",
+			Value: t.Cep,
+			Rules: safe.Rules{safe.CEP()},
+		},
+	}
+}
+
+func (t *taggedOrder) SafeFields() safe.Fields {
+	if t == nil {
+		return nil
+	}
+	return safe.Fields{
+		{
+			Name:     "customer",
+			Value:    t.Customer,
+			Rules:    safe.Rules{safe.Required()},
+			Children: t.Customer.SafeFields(),
+		},
+		{
+			Name:     "shipping",
+			Value:    t.Shipping,
+			Children: t.Shipping.SafeFields(),
+		},
+		{
+			Name:     "billing",
+			Value:    t.Billing,
+			Children: t.Billing.SafeFields(),
+		},
+		{
+			Name:  "items",
+			Value: t.Items,
+			Rules: safe.Rules{safe.Required()},
+			Elements: func() []safe.Fields {
+				safegenElements := make([]safe.Fields, len(t.Items))
+				for safegenIndex := range safegenElements {
+					safegenElements[safegenIndex] = t.Items[safegenIndex].SafeFields()
+				}
+				return safegenElements
+			}(),
+		},
+	}
+}
+
+func (t *taggedItem) SafeFields() safe.Fields {
+	if t == nil {
+		return nil
+	}
+	return safe.Fields{
+		{
+			Name:  "sku",
+			Value: t.Sku,
+			Rules: safe.Rules{safe.Required(), safe.Max(8)},
+		},
+		{
+			Name:  "quantity",
+			Value: t.Quantity,
+			Rules: safe.Rules{safe.Min(1)},
+		},
+	}
+}
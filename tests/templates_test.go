@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cayo-rodrigues/safe"
+)
+
+func TestMessageTemplates(t *testing.T) {
+	fields := safe.Fields{
+		{
+			Name:  "email",
+			Label: "E-mail",
+			Value: "pepsiman@pepsi.com",
+			Rules: safe.Rules{safe.Max(8).WithMessage("O campo {label} deve ter no máximo {max} caracteres")},
+		},
+		{
+			Name:  "nickname",
+			Value: "pe",
+			Rules: safe.Rules{safe.Min(3).WithMessage("{label}: \"{value}\" tem menos de {min} caracteres")},
+		},
+		{
+			Name: "address",
+			Children: safe.Fields{
+				{Name: "cep", Label: "CEP", Value: "", Rules: safe.Rules{safe.Required().WithMessage("{label} ({field}) é obrigatório")}},
+			},
+		},
+		{
+			Name:  "role",
+			Value: "pepsiman",
+			Rules: safe.Rules{safe.OneOf([]string{"admin", "guest"}).WithMessage("Sem placeholders")},
+		},
+	}
+
+	expectedErrs := safe.ErrorMessages{
+		"email":       "O campo E-mail deve ter no máximo 8 caracteres",
+		"nickname":    "nickname: \"pe\" tem menos de 3 caracteres",
+		"address.cep": "CEP (address.cep) é obrigatório",
+		"role":        "Sem placeholders",
+	}
+
+	errs, ok := safe.Validate(fields)
+	if ok || len(errs) != len(expectedErrs) {
+		t.Fatalf("Expected error messages: %s. Got: %s.", expectedErrs, errs)
+	}
+
+	for name, expectedMsg := range expectedErrs {
+		if msg := errs[name]; msg != expectedMsg {
+			t.Errorf("Expected %s error message: \"%s\". Got: \"%s\"", name, expectedMsg, msg)
+		}
+	}
+}
+
+func TestWithMessageFunc(t *testing.T) {
+	var got safe.MessageContext
+
+	fields := safe.Fields{
+		{
+			Name:  "email",
+			Label: "E-mail",
+			Value: "pepsiman@pepsi.com",
+			Rules: safe.Rules{safe.Max(8).WithMessageFunc(func(ctx safe.MessageContext) string {
+				got = ctx
+				return fmt.Sprintf("%s is too long (%s)", ctx.Label, ctx.Locale)
+			})},
+		},
+	}
+
+	errs, ok := safe.ValidateWithLocale(fields, "en")
+	if ok {
+		t.Fatalf("fields should not be valid")
+	}
+
+	if msg := errs["email"]; msg != "E-mail is too long (en)" {
+		t.Errorf("Expected email error message: \"E-mail is too long (en)\". Got: \"%s\"", msg)
+	}
+
+	if got.Field != "email" || got.Label != "E-mail" || got.Value != "pepsiman@pepsi.com" ||
+		got.Rule != "safe.Max" || got.Code != safe.MaxCharsCode || got.Params["max"] != 8 {
+		t.Errorf("Unexpected message context: %+v", got)
+	}
+}
+
+func TestCatalogTemplatesWithLabels(t *testing.T) {
+	safe.RegisterLocale("pt-label", safe.Catalog{
+		safe.RequiredCode: {Other: "O campo {label} é obrigatório"},
+		safe.MaxCharsCode: {One: "{label}: máximo de {max} caractere", Other: "{label}: máximo de {max} caracteres", Count: "max"},
+	})
+
+	fields := safe.Fields{
+		{Name: "name", Label: "Nome", Value: "", Rules: safe.Rules{safe.Required()}},
+		{Name: "initial", Value: "pe", Rules: safe.Rules{safe.Max(1)}},
+	}
+
+	expectedErrs := safe.ErrorMessages{
+		"name":    "O campo Nome é obrigatório",
+		"initial": "initial: máximo de 1 caractere",
+	}
+
+	errs, _ := safe.ValidateWithLocale(fields, "pt-label")
+	for name, expectedMsg := range expectedErrs {
+		if msg := errs[name]; msg != expectedMsg {
+			t.Errorf("Expected %s error message: \"%s\". Got: \"%s\"", name, expectedMsg, msg)
+		}
+	}
+}
+
+func TestStructFieldLabels(t *testing.T) {
+	user := newTaggedUser()
+
+	for _, fields := range []safe.Fields{safe.StructFields(user), user.SafeFields()} {
+		for _, field := range fields {
+			expectedLabel := ""
+			if field.Name == "name" {
+				expectedLabel = "Nome"
+			}
+			if field.Label != expectedLabel {
+				t.Errorf("Expected %s label: \"%s\". Got: \"%s\"", field.Name, expectedLabel, field.Label)
+			}
+		}
+	}
+}
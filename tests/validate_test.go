@@ -9,6 +9,7 @@ import (
 
 func TestValidationSuccess(t *testing.T) {
 	user := newSampleUser()
+	user.Phone = samplePhoneWithDDD
 
 	fields := sampleFields(user)
 
@@ -73,3 +74,205 @@ func TestValidationFailure(t *testing.T) {
 		}
 	}
 }
+
+func TestNestedValidation(t *testing.T) {
+	user := newSampleUser()
+	user.sampleAddress.Cep = "123"
+
+	fields := safe.Fields{
+		{
+			Name:  "name",
+			Value: user.Name,
+			Rules: safe.Rules{safe.Required()},
+		},
+		{
+			Name:  "address",
+			Value: user.sampleAddress,
+			Rules: safe.Rules{safe.Required()},
+			Children: safe.Fields{
+				{
+					Name:  "street",
+					Value: user.sampleAddress.Street,
+					Rules: safe.Rules{safe.Required()},
+				},
+				{
+					Name:  "cep",
+					Value: user.sampleAddress.Cep,
+					Rules: safe.Rules{safe.CEP()},
+				},
+			},
+		},
+		{
+			Name:  "items",
+			Value: []string{"q", "", "e"},
+			Elements: []safe.Fields{
+				{{Name: "sku", Value: "q", Rules: safe.Rules{safe.Required()}}},
+				{{Name: "sku", Value: "", Rules: safe.Rules{safe.Required()}}},
+				{{Name: "sku", Value: "e", Rules: safe.Rules{safe.Required()}}},
+			},
+		},
+	}
+
+	errs, ok := safe.Validate(fields)
+
+	expectedErrs := safe.ErrorMessages{
+		"address.cep":  safe.InvalidFormatMsg,
+		"items[1].sku": safe.MandatoryFieldMsg,
+	}
+
+	if ok || len(errs) != len(expectedErrs) {
+		t.Fatalf("Expected error messages %s. Got: %s", expectedErrs, errs)
+	}
+	for name, expectedMsg := range expectedErrs {
+		if msg := errs[name]; msg != expectedMsg {
+			t.Errorf("Expected %s error message: \"%s\". Got: \"%s\"", name, expectedMsg, msg)
+		}
+	}
+}
+
+func TestNestedValidationNilParent(t *testing.T) {
+	var address *sampleAddress
+
+	fields := safe.Fields{
+		{
+			Name:     "address",
+			Value:    address,
+			Children: safe.Fields{{Name: "street", Rules: safe.Rules{safe.Required()}}},
+		},
+	}
+
+	errs, ok := safe.Validate(fields)
+
+	if !ok || errs != nil {
+		t.Errorf("Children of a nil parent should be skipped. Got: %s", errs)
+	}
+
+	fields[0].Rules = safe.Rules{safe.Required()}
+
+	errs, ok = safe.Validate(fields)
+
+	if ok || len(errs) != 1 || errs["address"] != safe.MandatoryFieldMsg {
+		t.Errorf("A required nil parent should not be valid. Got: %s", errs)
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	user := newSampleUser()
+	user.Name = "pe"
+	user.Password = "pepsi"
+
+	fields := safe.Fields{
+		{
+			Name:  "name",
+			Value: user.Name,
+			Rules: safe.Rules{safe.Required(), safe.Min(3), safe.Max(128)},
+		},
+		{
+			Name:  "password",
+			Value: user.Password,
+			Rules: safe.Rules{safe.Required(), safe.Min(8), safe.StrongPassword(), safe.Max(4).WithMessage("too long")},
+		},
+		{
+			Name:  "email",
+			Value: user.Email,
+			Rules: safe.Rules{safe.Required(), safe.Email()},
+		},
+	}
+
+	errs, ok := safe.ValidateAll(fields)
+
+	expectedErrs := safe.AllErrorMessages{
+		"name":     {safe.MinCharsMsg(3)},
+		"password": {safe.MinCharsMsg(8), safe.WeakPasswordMsg, "too long"},
+	}
+
+	if ok || len(errs) != len(expectedErrs) {
+		t.Fatalf("Expected error messages %s. Got: %s", &expectedErrs, &errs)
+	}
+	for name, expectedMsgs := range expectedErrs {
+		msgs := errs[name]
+		if len(msgs) != len(expectedMsgs) {
+			t.Errorf("Expected %s error messages: %q. Got: %q", name, expectedMsgs, msgs)
+			continue
+		}
+		for i := range expectedMsgs {
+			if msgs[i] != expectedMsgs[i] {
+				t.Errorf("Expected %s error messages: %q. Got: %q", name, expectedMsgs, msgs)
+			}
+		}
+	}
+
+	defaultErrs, _ := safe.Validate(fields)
+	if defaultErrs["password"] != safe.MinCharsMsg(8) {
+		t.Errorf("safe.Validate should still stop at the first broken rule. Got: %s", defaultErrs["password"])
+	}
+
+	user = newSampleUser()
+	user.Phone = samplePhoneWithDDD
+	errs, ok = safe.ValidateAll(sampleFields(user))
+
+	if !ok || errs != nil {
+		t.Errorf("User should be valid and have no error messages.\nValid: %v.\nError messages: %s.", ok, &errs)
+	}
+}
+
+func TestEachValidation(t *testing.T) {
+	fields := safe.Fields{
+		{
+			Name:  "emails",
+			Value: []string{"a@b.com", "not an email", "", "c@d.com"},
+			Rules: safe.Rules{safe.Required(), safe.Each(safe.Required(), safe.Email())},
+		},
+		{
+			Name:  "scores",
+			Value: [3]int{5, -1, 11},
+			Rules: safe.Rules{safe.Each(safe.Min(0), safe.Max(10))},
+		},
+		{
+			Name:  "dependents",
+			Value: [][]string{{"393.546.320-09"}, {"111.111.111-11", "123"}},
+			Rules: safe.Rules{safe.Each(safe.Each(safe.Cpf()))},
+		},
+		{
+			Name:  "tags",
+			Value: []string(nil),
+			Rules: safe.Rules{safe.Each(safe.Required())},
+		},
+	}
+
+	errs, ok := safe.Validate(fields)
+
+	expectedErrs := safe.ErrorMessages{
+		"emails[1]":        safe.InvalidFormatMsg,
+		"emails[2]":        safe.MandatoryFieldMsg,
+		"scores[1]":        safe.MinValueMsg(0),
+		"scores[2]":        safe.MaxValueMsg(10),
+		"dependents[1][0]": safe.InvalidFormatMsg,
+		"dependents[1][1]": safe.InvalidFormatMsg,
+	}
+
+	if ok || len(errs) != len(expectedErrs) {
+		t.Fatalf("Expected error messages %v. Got: %v", expectedErrs, errs)
+	}
+	for name, expectedMsg := range expectedErrs {
+		if errs[name] != expectedMsg {
+			t.Errorf("Expected %s error message: %q. Got: %q", name, expectedMsg, errs[name])
+		}
+	}
+
+	fields = safe.Fields{
+		{
+			Name:  "emails",
+			Value: []string{"", "a@b.com"},
+			Rules: safe.Rules{safe.Each(safe.Required(), safe.Email(), safe.Min(10))},
+		},
+	}
+
+	allErrs, ok := safe.ValidateAll(fields)
+	if expected := []string{safe.MandatoryFieldMsg}; ok || len(allErrs) != 2 || len(allErrs["emails[0]"]) != 1 || allErrs["emails[0]"][0] != expected[0] {
+		t.Errorf("Expected emails[0] error messages: %q, and an error for emails[1]. Got: %v", expected, allErrs)
+	}
+	if expected := []string{safe.MinCharsMsg(10)}; len(allErrs["emails[1]"]) != 1 || allErrs["emails[1]"][0] != expected[0] {
+		t.Errorf("Expected emails[1] error messages: %q. Got: %q", expected, allErrs["emails[1]"])
+	}
+}
@@ -42,6 +42,10 @@ func newSampleUser() *sampleUser {
 	}
 }
 
+// safe.Phone requires the area code (DDD), so the phone of newSampleUser, which has none, is not valid.
+// Tests that expect the sample user to be valid use this one instead.
+const samplePhoneWithDDD = "+55 35 99988-7766"
+
 func sampleJobs() []string {
 	return []string{"software developer", "designer", "devops engineer", "po", "techlead", "scrum master", "ceo", "marketing", "sales", "cs", "spider-man", ""}
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"reflect"
 )
 
 // A slice of fields to be validated.
@@ -68,15 +69,36 @@ func (fields *Fields) SetField(fieldName string, newField *Field) *Fields {
 // It is highly advisable to use safe.Fields instead, since safe.Validate expects safe.Fields as argument.
 type Field struct {
 	// This is the name used as a key in the ErrorMessages map when the field is not valid
-	Name  string
+	Name string
+	// A human friendly name, available to messages as the {label} placeholder. Defaults to Name.
+	Label string
 	Value any
 	Rules Rules
+	// Fields nested inside this one, like the fields of an address inside a user.
+	//
+	// In the ErrorMessages map, their names are prefixed with the name of the parent, as in "address.cep".
+	Children Fields
+	// The fields of each element of a list, like the items of an order.
+	//
+	// In the ErrorMessages map, their names are prefixed with the name of the parent and the index
+	// of the element, as in "items[2].sku".
+	Elements []Fields
 }
 
 func (f *Field) String() string {
 	return fmt.Sprintf("{ Name: %s, Value: %v, Rules: %s }", f.Name, f.Value, f.Rules)
 }
 
+// Tells if the children and elements of a field should be validated.
+//
+// They are skipped when the value of the field is a nil pointer, slice or map, in which case
+// only the rules of the field itself apply (for instance, safe.Required).
+//
+// A field without a value at all (Value is not set) is just a group of children.
+func (f *Field) hasNestedValue() bool {
+	return f.Value == nil || !isNil(f.Value)
+}
+
 // safe.Validate returns (safe.ErrorMessages, bool).
 //
 // safe.ErrorMessages is a map of field names, each associated with a message.
@@ -124,6 +146,8 @@ func (errors *ErrorMessages) JSON() []byte {
 //
 // 2) A bool, indicating if all fields are valid or not. In case this is true, ErrorMessages is nil.
 //
+// Children and Elements of a Field are validated after the Field itself, but only if it is valid.
+//
 // Example usage:
 //
 //	fields := safe.Fields{
@@ -134,22 +158,151 @@ func (errors *ErrorMessages) JSON() []byte {
 //	fmt.Println("are all fields valid?", ok)
 //	fmt.Println("is there any error message?", errors)
 func Validate(fields Fields) (ErrorMessages, bool) {
-	var messages ErrorMessages
+	messages := validationErrors(fields, validation{}).ErrorMessages()
+	return messages, len(messages) == 0
+}
+
+// A map of field names, each associated with all the messages of the rules it broke.
+//
+// It is returned by safe.ValidateAll.
+type AllErrorMessages map[string][]string
+
+// Implements the error interface. Calls the JSON method and converts it to string.
+func (errors *AllErrorMessages) Error() string {
+	return string(errors.JSON())
+}
+
+// Returns the JSON version of AllErrorMessages
+//
+// In case of error, returns an empty []byte
+func (errors *AllErrorMessages) JSON() []byte {
+	errorsJson, err := json.Marshal(errors)
+	if err != nil {
+		log.Printf("Could not marshal AllErrorMessages to json: %v\n", err)
+		return []byte{}
+	}
+	return errorsJson
+}
+
+// Just like safe.Validate, except that all the Rules of a Field are evaluated, instead of stopping at the first one that fails.
+//
+// This is useful when you want to show every unmet requirement at once, like in password forms.
+//
+// ValidateAll returns two values:
+//
+// 1) AllErrorMessages, a map in which the keys correspond to the Field.Name property, and the values are
+// the error messages of every broken rule, in the same order as the Rules.
+//
+// 2) A bool, indicating if all fields are valid or not. In case this is true, AllErrorMessages is nil.
+//
+// Example usage:
+//
+//	fields := safe.Fields{
+//		{
+//			Name:  "password",
+//			Value: u.Password,
+//			Rules: safe.Rules{safe.Min(12), safe.StrongPassword()},
+//		},
+//	}
+//	errors, ok := safe.ValidateAll(fields)
+//
+//	fmt.Println("what is wrong with the password?", errors["password"])
+func ValidateAll(fields Fields) (AllErrorMessages, bool) {
+	messages := validationErrors(fields, validation{all: true}).AllErrorMessages()
+	return messages, len(messages) == 0
+}
+
+// How a validation should be performed.
+type validation struct {
+	// When false, only the first broken rule of each field is reported
+	all    bool
+	locale string
+}
 
+// Walks through fields and their children, calling onFail for each broken rule, along with the path of the field.
+func validateFields(fields Fields, prefix string, v validation, onFail func(path string, rs *RuleSet)) {
 	for _, field := range fields {
+		path := prefix + field.Name
+		isValid := true
+
 		for _, rs := range field.Rules {
 			rs.FieldValue = field.Value
-			isValid := rs.ValidateFunc(rs)
-			if !isValid {
-				if messages == nil {
-					messages = make(ErrorMessages)
+			rs.FieldName = path
+			rs.FieldLabel = field.Label
+			if rs.FieldLabel == "" {
+				rs.FieldLabel = field.Name
+			}
+			rs.Locale = v.locale
+
+			if rs.each != nil && isList(rs.FieldValue) {
+				if !validateEach(rs, v, onFail) {
+					isValid = false
+					if !v.all {
+						break
+					}
+				}
+				continue
+			}
+
+			if !rs.ValidateFunc(rs) {
+				isValid = false
+				onFail(path, rs)
+				if !v.all {
+					break // stop runing validate funcs after first fail
+				}
+			}
+		}
+
+		if !isValid || !field.hasNestedValue() {
+			continue
+		}
+
+		validateFields(field.Children, path+".", v, onFail)
+		for i, element := range field.Elements {
+			validateFields(element, fmt.Sprintf("%s[%d].", path, i), v, onFail)
+		}
+	}
+}
+
+// Applies the rules of safe.Each to each element of the field, calling onFail with the path of the element,
+// as in "emails[3]". Nested safe.Each rules are applied to the elements of each element.
+func validateEach(rs *RuleSet, v validation, onFail func(path string, rs *RuleSet)) bool {
+	isValid := true
+	list := reflect.ValueOf(rs.FieldValue)
+
+	for i := 0; i < list.Len(); i++ {
+		path := fmt.Sprintf("%s[%d]", rs.FieldName, i)
+
+		for _, rule := range rs.each {
+			rule.FieldValue = list.Index(i).Interface()
+			rule.FieldName = path
+			rule.FieldLabel = rs.FieldLabel
+			rule.Locale = rs.Locale
+
+			var isRuleValid bool
+			if rule.each != nil && isList(rule.FieldValue) {
+				isRuleValid = validateEach(rule, v, onFail)
+			} else {
+				isRuleValid = rule.ValidateFunc(rule)
+				if !isRuleValid {
+					onFail(path, rule)
+				}
+			}
+
+			if !isRuleValid {
+				isValid = false
+				if !v.all {
+					break
 				}
-				msg := rs.MessageFunc(rs)
-				messages[field.Name] = msg
-				break // stop runing validate funcs after first fail
 			}
 		}
 	}
 
-	return messages, len(messages) == 0
+	return isValid
+}
+
+// Tells if val is a slice or an array.
+func isList(val any) bool {
+	kind := reflect.ValueOf(val).Kind()
+	return kind == reflect.Slice || kind == reflect.Array
 }
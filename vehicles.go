@@ -0,0 +1,101 @@
+package safe
+
+import "strings"
+
+// A helper function to convert an old plate, like "ABC-1234", into the Mercosul format, like "ABC1C34".
+//
+// The second digit becomes a letter, from A (0) to J (9). Mercosul plates are returned as they are.
+//
+// The second result is false when the plate is not valid.
+func PlateToMercosul(plate string) (string, bool) {
+	plate = strings.ToUpper(strings.TrimSpace(plate))
+
+	if MercosulPlateRegex.MatchString(plate) {
+		return plate, true
+	}
+	if !PlateRegex.MatchString(plate) {
+		return "", false
+	}
+
+	plate = stripSymbols(plate, "-")
+	return plate[:4] + string(rune('A'+plate[4]-'0')) + plate[5:], true
+}
+
+// A helper function to convert a Mercosul plate, like "ABC1C34", into the old format, like "ABC-1234".
+//
+// The letter becomes a digit, from 0 (A) to 9 (J), so only Mercosul plates converted from old ones may be
+// converted back. Old plates are returned with a dash.
+//
+// The second result is false when the plate is not valid, or can't be converted.
+func PlateToOld(plate string) (string, bool) {
+	plate = strings.ToUpper(strings.TrimSpace(plate))
+
+	if PlateRegex.MatchString(plate) {
+		plate = stripSymbols(plate, "-")
+		return plate[:3] + "-" + plate[3:], true
+	}
+	if !MercosulPlateRegex.MatchString(plate) || plate[4] > 'J' {
+		return "", false
+	}
+
+	return plate[:3] + "-" + plate[3:4] + string(rune('0'+plate[4]-'A')) + plate[5:], true
+}
+
+// A helper function to determine if a RENAVAM is valid, verifying its check digit.
+//
+// It must have 11 digits. Former 9 digit RENAVAMs must be padded with zeros on the left.
+func IsValidRenavam(renavam string) bool {
+	digits, ok := parseDigits(renavam)
+	if !ok || len(digits) != 11 {
+		return false
+	}
+
+	checkDigit := weightedSum(digits[:10], []int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) * 10 % 11
+	if checkDigit == 10 {
+		checkDigit = 0
+	}
+	return digits[10] == checkDigit
+}
+
+// The values of each letter in a VIN, used by its check digit.
+var vinValues = map[rune]int{
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+var vinWeights = []int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// A helper function to determine if a VIN (vehicle identification number, or chassi) is valid,
+// with 17 characters, without I, O and Q, as prescribed by ISO 3779. Lowercase letters are accepted.
+//
+// The check digit is not verified, since ISO 3779 does not require it, and brazilian and european chassis
+// often have a filler at the 9th position, like "9BWZZZ377VT004251". Please refer to safe.IsValidVINCheckDigit.
+func IsValidVIN(vin string) bool {
+	return VINRegex.MatchString(strings.ToUpper(vin))
+}
+
+// A helper function to determine if a VIN is valid, as in safe.IsValidVIN, and the check digit at the 9th position
+// matches the weighted sum of the others, as required in North America (49 CFR 565).
+// The check digit is either a digit or X, for 10.
+func IsValidVINCheckDigit(vin string) bool {
+	vin = strings.ToUpper(vin)
+	if !VINRegex.MatchString(vin) {
+		return false
+	}
+
+	values := make([]int, 0, len(vin))
+	for _, r := range vin {
+		if r >= '0' && r <= '9' {
+			values = append(values, int(r-'0'))
+			continue
+		}
+		values = append(values, vinValues[r])
+	}
+
+	checkDigit := byte('0' + weightedSum(values, vinWeights)%11)
+	if checkDigit == '0'+10 {
+		checkDigit = 'X'
+	}
+	return vin[8] == checkDigit
+}